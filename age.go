@@ -0,0 +1,40 @@
+package buildinfo
+
+import (
+	"log"
+	"time"
+)
+
+// Age returns the time since this binary was built. It returns 0 if the build
+// time isn't available.
+func Age() time.Duration {
+	if buildTimeUnix == "0" {
+		return 0
+	}
+	return time.Since(buildTime)
+}
+
+// IsOlderThan returns true if this binary was built more than d ago. It returns
+// false if the build time isn't available.
+func IsOlderThan(d time.Duration) bool {
+	return Age() > d
+}
+
+// WarnIfOlderThan calls warn with the Age() if this binary was built more than
+// d ago, and reports whether it did. It is meant to be called at startup, to
+// let long running services self-report that they are running a stale build.
+// If warn is nil, the warning is logged using the log package.
+func WarnIfOlderThan(d time.Duration, warn func(age time.Duration)) bool {
+	age := Age()
+	if age <= d {
+		return false
+	}
+	if warn == nil {
+		warn = func(age time.Duration) {
+			log.Printf("running build %s of version %s which is %v old",
+				buildHash, releaseVersion, age.Truncate(time.Second))
+		}
+	}
+	warn(age)
+	return true
+}
@@ -0,0 +1,87 @@
+package buildinfo
+
+import (
+	"strings"
+	"sync"
+)
+
+// APIVersionInfo describes the state of an API version in this release.
+type APIVersionInfo struct {
+	APIVersion string
+	Active     bool
+	Deprecated bool
+	RemovedIn  string
+}
+
+type registeredAPIVersion struct {
+	name            string
+	since           *semver
+	deprecatedSince *semver
+	removedIn       *semver
+	removedInRaw    string
+}
+
+var (
+	apiVersionsMu sync.Mutex
+	apiVersions   []registeredAPIVersion
+)
+
+// RegisterAPIVersion registers an API version that is active from the since
+// release until the removedIn release, and deprecated from the
+// deprecatedSince release. Any of the releases may be empty to leave that
+// bound unset. It panics if a non-empty release is not a semantic version.
+func RegisterAPIVersion(apiVersion, since, deprecatedSince, removedIn string) {
+	v := registeredAPIVersion{
+		name:            apiVersion,
+		since:           mustParseBound(apiVersion, since),
+		deprecatedSince: mustParseBound(apiVersion, deprecatedSince),
+		removedIn:       mustParseBound(apiVersion, removedIn),
+		removedInRaw:    removedIn,
+	}
+	apiVersionsMu.Lock()
+	defer apiVersionsMu.Unlock()
+	for i, e := range apiVersions {
+		if e.name == apiVersion {
+			apiVersions[i] = v
+			return
+		}
+	}
+	apiVersions = append(apiVersions, v)
+}
+
+// SupportedAPIVersions returns the registered API versions, in registration
+// order, with their state based on ReleaseVersion(). API versions are never
+// active when ReleaseVersion() is not a semantic version.
+func SupportedAPIVersions() []APIVersionInfo {
+	release, ok := parseSemver(ReleaseVersion())
+	apiVersionsMu.Lock()
+	defer apiVersionsMu.Unlock()
+	infos := make([]APIVersionInfo, 0, len(apiVersions))
+	for _, v := range apiVersions {
+		info := APIVersionInfo{APIVersion: v.name, RemovedIn: v.removedInRaw}
+		if ok {
+			info.Active = (v.since == nil || release.compare(*v.since) >= 0) &&
+				(v.removedIn == nil || release.compare(*v.removedIn) < 0)
+			info.Deprecated = v.deprecatedSince != nil &&
+				release.compare(*v.deprecatedSince) >= 0
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// activeAPIVersions returns a human readable list of the active API versions.
+func activeAPIVersions() string {
+	var active []string
+	for _, v := range SupportedAPIVersions() {
+		if !v.Active {
+			continue
+		}
+		if v.Deprecated {
+			active = append(active, v.APIVersion+" (deprecated)")
+		} else {
+			active = append(active, v.APIVersion)
+		}
+	}
+	return strings.Join(active, ", ")
+}
@@ -0,0 +1,26 @@
+package buildinfo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// AssetVersion returns a short URL-safe token identifying this build, for
+// cache-busting static asset URLs. It is derived from the build hash and time,
+// and for "dev" builds also from the StartupTime(), so assets are refetched
+// after a restart during development.
+func AssetVersion() string {
+	h := sha256.New()
+	h.Write([]byte(buildHash + "\x00" + buildTimeUnix))
+	if buildHash == "dev" {
+		h.Write([]byte("\x00" + strconv.FormatInt(startupTime.UnixNano(), 10)))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:10]
+}
+
+// ETag returns a strong HTTP entity tag for responses that only change with
+// the build, such as embedded static assets.
+func ETag() string {
+	return `"` + AssetVersion() + `"`
+}
@@ -0,0 +1,48 @@
+package buildinfo
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// WithModuleCount includes the number of modules in the Banner.
+func WithModuleCount() Option {
+	return func(o *options) { o.moduleCount = true }
+}
+
+// Banner writes a compact, single line startup banner to w, such as:
+//
+//	myapp v1.4.2 (abc1234, built 3d ago, go1.22.1)
+func Banner(w io.Writer, appName string, opts ...Option) error {
+	o := newOptions(opts)
+	details := []string{buildHash}
+	if buildTimeUnix != "0" {
+		details = append(details, "built "+compactAge(Age())+" ago")
+	}
+	details = append(details, runtime.Version())
+	if o.moduleCount && modules != nil {
+		if len(modules) == 1 {
+			details = append(details, "1 module")
+		} else {
+			details = append(details, fmt.Sprintf("%d modules", len(modules)))
+		}
+	}
+	_, err := fmt.Fprintf(w, "%s %s (%s)\n", appName, displayVersion(), strings.Join(details, ", "))
+	return err
+}
+
+// compactAge formats d using its largest unit, such as "3d", "5h" or "12m".
+func compactAge(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return fmt.Sprintf("%ds", d/time.Second)
+}
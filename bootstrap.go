@@ -0,0 +1,51 @@
+package buildinfo
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	bootstrapMu   sync.Mutex
+	bootstrapTime time.Time
+)
+
+// MarkBootstrapComplete records that the service has completed its bootstrap.
+// Only the first call has any effect.
+func MarkBootstrapComplete() {
+	bootstrapMu.Lock()
+	defer bootstrapMu.Unlock()
+	if bootstrapTime.IsZero() {
+		bootstrapTime = time.Now()
+	}
+}
+
+// BootstrapDuration returns the time from StartupTime() until
+// MarkBootstrapComplete was called. It returns false if the bootstrap has not
+// been marked complete.
+func BootstrapDuration() (time.Duration, bool) {
+	bootstrapMu.Lock()
+	defer bootstrapMu.Unlock()
+	if bootstrapTime.IsZero() {
+		return 0, false
+	}
+	return bootstrapTime.Sub(startupTime), true
+}
+
+// BootstrapDeadline returns an error if the bootstrap took longer than d. If
+// the bootstrap has not been marked complete, the time since StartupTime() is
+// used instead.
+func BootstrapDeadline(d time.Duration) error {
+	took, ok := BootstrapDuration()
+	if !ok {
+		took = time.Since(startupTime)
+	}
+	if took > d {
+		if !ok {
+			return fmt.Errorf("buildinfo: bootstrap incomplete after %v, deadline %v", took, d)
+		}
+		return fmt.Errorf("buildinfo: bootstrap took %v, deadline %v", took, d)
+	}
+	return nil
+}
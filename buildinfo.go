@@ -21,14 +21,33 @@
 //      -ldflags "$LDFLAGS" \
 //      -o myapp \
 //      github.com/me/myapp
+//
+// The build time may also be provided as Unix milliseconds or RFC3339, such as
+// $(date -u +%Y-%m-%dT%H:%M:%SZ), and the format is detected automatically.
+//
+// Additional details about the commit may be provided the same way:
+//
+//    LDFLAGS="$LDFLAGS -X github.com/daaku/buildinfo.buildBranch=$(git rev-parse --abbrev-ref HEAD)"
+//    LDFLAGS="$LDFLAGS -X github.com/daaku/buildinfo.buildTag=$(git describe --tags --exact-match)"
+//    LDFLAGS="$LDFLAGS -X 'github.com/daaku/buildinfo.commitSubject=$(git log -1 --format=%s)'"
+//    LDFLAGS="$LDFLAGS -X github.com/daaku/buildinfo.buildDirty=$(test -z "$(git status --porcelain)" || echo true)"
+//
+// A release that has been retracted or deprecated can announce so at runtime by
+// additionally setting the isRetracted and deprecatedMessage variables:
+//
+//    LDFLAGS="$LDFLAGS -X github.com/daaku/buildinfo.isRetracted=true"
+//    LDFLAGS="$LDFLAGS -X 'github.com/daaku/buildinfo.deprecatedMessage=use v2'"
 package buildinfo
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"runtime"
 	"runtime/debug"
 	"strconv"
+	"strings"
 	"text/tabwriter"
 	"time"
 )
@@ -41,54 +60,206 @@ var (
 	buildURL       = ""
 	releaseVersion = "dev"
 
+	buildBranch   = ""
+	buildTag      = ""
+	commitSubject = ""
+	buildDirty    = ""
+
+	deployEnvironment = ""
+
+	isRetracted       = "false"
+	deprecatedMessage = ""
+
 	buildTime time.Time
+	retracted bool
+	dirty     bool
 
-	buildInfo  []byte
+	buildFields []field
 	moduleInfo string
+	modules    []Module
+	mainModule Module
+	goSettings []debug.BuildSetting
+
+	initErr error
 )
 
 func init() {
-	buildTimeUnixI, err := strconv.ParseInt(buildTimeUnix, 0, 0)
+	bi, biOK := debug.ReadBuildInfo()
+	if biOK {
+		mainModule = newModule(&bi.Main)
+		goSettings = bi.Settings
+		loadBuildSettings(bi.Settings)
+		modules = make([]Module, 0, len(bi.Deps))
+		for _, m := range bi.Deps {
+			modules = append(modules, newModule(m))
+		}
+	}
+	injected = injectedValues()
+	derive()
+}
+
+// derive computes the build information from the injected, embedded and
+// overridden values, falling back to the information stamped by the go
+// command. It starts over from the injected values, so it may be called again
+// whenever the embedded or overridden values change.
+func derive() {
+	for key, i := range injectable {
+		*i.v = injected[key]
+		if value, ok := embedded[key]; ok && *i.v == i.dflt {
+			*i.v = value
+		}
+	}
+	for key, value := range overrides {
+		*injectable[key].v = value
+	}
+	initErr = nil
+	dirty = false
+	applyVCSFallback(goSettings)
+	if buildDirty != "" {
+		dirty, _ = strconv.ParseBool(buildDirty)
+	}
+	buildHash = dirtyHash(buildHash, dirty)
+	if releaseVersion == "dev" && mainModule.Version != "" && mainModule.Version != "(devel)" {
+		releaseVersion = mainModule.Version
+	}
+
+	var err error
+	buildTime, err = parseBuildTime(buildTimeUnix)
 	if err != nil {
-		panic(err)
+		initErr = err
+		buildTime = time.Unix(0, 0)
 	}
+	buildTimeUnix = strconv.FormatInt(buildTime.Unix(), 10)
+
+	retracted, _ = strconv.ParseBool(isRetracted)
 
-	buildTime = time.Unix(buildTimeUnixI, 0)
+	render()
+}
+
+// msThreshold is the value above which a numeric build time is taken to be in
+// milliseconds. As seconds it would be in the year 5138.
+const msThreshold = 1e11
+
+// parseBuildTime parses a build time provided as Unix seconds, Unix
+// milliseconds or RFC3339.
+func parseBuildTime(s string) (time.Time, error) {
+	if n, err := strconv.ParseInt(s, 0, 64); err == nil {
+		if n > msThreshold || n < -msThreshold {
+			return time.UnixMilli(n), nil
+		}
+		return time.Unix(n, 0), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("buildinfo: invalid buildTimeUnix %q: "+
+			"must be Unix seconds, Unix milliseconds or RFC3339", s)
+	}
+	return t, nil
+}
 
-	info := bytes.Buffer{}
-	fmt.Fprintf(&info, "Release Version:\t%s\n", releaseVersion)
-	fmt.Fprintf(&info, "Go Version:\t%s\n", runtime.Version())
-	fmt.Fprintf(&info, "Build Hash:\t%s\n", buildHash)
-	if buildURL != "" {
-		fmt.Fprintf(&info, "Build URL:\t%s\n", buildURL)
+// render populates the pre-rendered information from the current values.
+func render() {
+	buildFields = buildFields[:0]
+	add := func(name, value string) {
+		if value != "" {
+			buildFields = append(buildFields, field{name, value})
+		}
 	}
-	buildInfo = info.Bytes()
+	add("Release Version", releaseVersion)
+	add("Go Version", runtime.Version())
+	add("Build Hash", buildHash)
+	add("Build URL", redactURL(buildURL))
+	add("Branch", buildBranch)
+	add("Tag", buildTag)
+	add("Commit Subject", commitSubject)
+	add("Environment", deployEnvironment)
+	add("Deprecated", deprecatedMessage)
 
-	if bi, ok := debug.ReadBuildInfo(); ok {
+	moduleInfo = ""
+	if modules != nil {
 		info := bytes.Buffer{}
-		fmt.Fprint(&info, "Modules:\n")
-		tw := tabwriter.NewWriter(&info, 0, 0, 1, ' ', 0)
-		for _, m := range bi.Deps {
-			fmt.Fprintf(tw, "%s\t%s\n", m.Path, m.Version)
-		}
-		tw.Flush()
+		_ = writeModules(&info, redactModules(modules), ModuleInfoOpts{})
 		moduleInfo = info.String()
 	}
+
+	if b, err := json.Marshal(Get()); err == nil {
+		BuildInfoExport = string(b)
+	}
 }
 
-// ReleaseVersion returns the release version of this built binary. It may
-// return "dev" if a build version isn't avaiable.
+// applyVCSFallback populates the build hash and time from the VCS information
+// stamped by the go command, when they were not provided via ldflags.
+func applyVCSFallback(settings []debug.BuildSetting) {
+	hash, timeUnix, modified := vcsValues(settings)
+	if buildHash == "dev" && hash != "" {
+		buildHash = hash
+	}
+	if buildTimeUnix == "0" && timeUnix != "" {
+		buildTimeUnix = timeUnix
+	}
+	dirty = modified
+}
+
+// vcsValues returns the build hash and unix build time derived from the VCS
+// information stamped by the go command, and whether the working tree was
+// modified. The hash and time may be blank.
+func vcsValues(settings []debug.BuildSetting) (hash, timeUnix string, modified bool) {
+	var vcsTime string
+	for _, s := range settings {
+		switch s.Key {
+		case "vcs.revision":
+			hash = s.Value
+		case "vcs.time":
+			vcsTime = s.Value
+		case "vcs.modified":
+			modified = s.Value == "true"
+		}
+	}
+	if len(hash) > 12 {
+		hash = hash[:12]
+	}
+	if vcsTime != "" {
+		if t, err := time.Parse(time.RFC3339, vcsTime); err == nil {
+			timeUnix = strconv.FormatInt(t.Unix(), 10)
+		}
+	}
+	return hash, timeUnix, modified
+}
+
+// dirtyHash adds the "-dirty" suffix to hash for builds from a modified working
+// tree.
+func dirtyHash(hash string, dirty bool) string {
+	if !dirty || hash == "dev" || strings.HasSuffix(hash, "-dirty") {
+		return hash
+	}
+	return hash + "-dirty"
+}
+
+// Err returns the error encountered while parsing the values provided via
+// ldflags, if any. When a value is invalid, the corresponding getter returns
+// the same value it would if the value had not been provided at all.
+func Err() error {
+	return initErr
+}
+
+// ReleaseVersion returns the release version of this built binary. If one
+// wasn't provided via ldflags, the version of the main module is used, which is
+// available for binaries built with "go install module@version". It may return
+// "dev" if a build version isn't avaiable.
 func ReleaseVersion() string {
 	return releaseVersion
 }
 
-// BuildHash returns the release hash of this built binary. It may
-// return "dev" if a build hash isn't avaiable.
+// BuildHash returns the release hash of this built binary. If one wasn't
+// provided via ldflags, the VCS revision stamped by the go command is used. It
+// has a "-dirty" suffix if Dirty() is true, and may return "dev" if a build
+// hash isn't avaiable.
 func BuildHash() string {
 	return buildHash
 }
 
-// BuildTime returns the time at which this binary was built.
+// BuildTime returns the time at which this binary was built. If one wasn't
+// provided via ldflags, the VCS commit time stamped by the go command is used.
 func BuildTime() time.Time {
 	return buildTime
 }
@@ -98,6 +269,54 @@ func BuildURL() string {
 	return buildURL
 }
 
+// BuildBranch returns the VCS branch this binary was built from. It may be
+// blank.
+func BuildBranch() string {
+	return buildBranch
+}
+
+// BuildTag returns the VCS tag this binary was built from. It may be blank.
+func BuildTag() string {
+	return buildTag
+}
+
+// CommitSubject returns the subject line of the commit this binary was built
+// from. It may be blank.
+func CommitSubject() string {
+	return commitSubject
+}
+
+// Dirty returns true if this binary was built from a modified working tree. It
+// is set using the buildDirty variable, or detected from the VCS information
+// stamped by the go command.
+func Dirty() bool {
+	return dirty
+}
+
+// DeployEnvironment returns the environment this binary is deployed to, such as
+// "staging" or "production". It may be blank.
+func DeployEnvironment() string {
+	return deployEnvironment
+}
+
+// IsRetracted returns true if this release has been retracted.
+func IsRetracted() bool {
+	return retracted
+}
+
+// DeprecationMessage returns the deprecation notice for this release. It will
+// be blank if the release is not deprecated.
+func DeprecationMessage() string {
+	return deprecatedMessage
+}
+
+// MainModule returns the path and version of the main module, as reported by
+// the go command. The version is "(devel)" when the binary was not built from
+// a versioned module.
+func MainModule() Module {
+	return mainModule
+}
+
 // StartupTime returns the time at which this binary was executed.
 func StartupTime() time.Time {
 	return startupTime
@@ -105,20 +324,78 @@ func StartupTime() time.Time {
 
 // BasicInfo returns a pretty-print version of various useful pieces of build
 // information.
-func BasicInfo() []byte {
+func BasicInfo(opts ...Option) []byte {
+	if w := newOptions(opts).w; w != nil {
+		_ = WriteBasicInfo(w, opts...)
+		return nil
+	}
 	var b bytes.Buffer
-	tw := tabwriter.NewWriter(&b, 0, 0, 1, ' ', 0)
-	if buildTimeUnix != "0" {
-		fmt.Fprintf(tw, "Build Time:\t%v (%v ago)\n", buildTime,
-			time.Since(buildTime).Truncate(time.Second))
+	_ = WriteBasicInfo(&b, opts...)
+	return b.Bytes()
+}
+
+// WriteBasicInfo writes BasicInfo(opts...) to w.
+func WriteBasicInfo(w io.Writer, opts ...Option) error {
+	o := newOptions(opts)
+	color := o.color.useColor(w)
+	sw := &stickyWriter{w: w}
+	if retracted {
+		if color {
+			fmt.Fprint(sw, ansiBold)
+		}
+		fmt.Fprint(sw, "WARNING: this version has been retracted\n")
+		if color {
+			fmt.Fprint(sw, ansiReset)
+		}
 	}
-	uptime := time.Since(startupTime).Truncate(time.Second)
-	if uptime != 0 {
-		fmt.Fprintf(tw, "Server Uptime:\t%v\n", uptime)
+	tw := tabwriter.NewWriter(sw, 0, 0, 1, ' ', 0)
+	for _, f := range basicFields(o) {
+		if !color {
+			fmt.Fprintf(tw, "%s:\t%s\n", f.Name, f.Value)
+			continue
+		}
+		// Every label has the same escapes, which keeps the columns aligned.
+		value := f.Value
+		if f.Name == "Release Version" || f.Name == "Build Hash" {
+			value = ansiBold + ansiCyan + value + ansiReset
+		}
+		fmt.Fprintf(tw, "%s%s:%s\t%s\n", ansiDim, f.Name, ansiReset, value)
 	}
-	_, _ = tw.Write(buildInfo)
 	_ = tw.Flush()
-	return b.Bytes()
+	return sw.err
+}
+
+// field is a named value in the BasicInfo() table.
+type field struct {
+	Name, Value string
+}
+
+// basicFields returns the current fields in the BasicInfo() table.
+func basicFields(o options) []field {
+	var fields []field
+	if buildTimeUnix != "0" {
+		fields = append(fields, field{"Build Time", fmt.Sprintf("%s (%s ago)",
+			o.formatTime(buildTime), humanizeDuration(time.Since(buildTime), o.precision))})
+	}
+	if Uptime() != 0 {
+		fields = append(fields, field{"Server Uptime", humanizeDuration(Uptime(), o.precision)})
+	}
+	if d, ok := BootstrapDuration(); ok {
+		fields = append(fields, field{"Bootstrap Duration", d.String()})
+	}
+	if v := activeAPIVersions(); v != "" {
+		fields = append(fields, field{"API Versions", v})
+	}
+	fields = append(fields, buildFields...)
+	if u := redactURL(ReleaseNotesURL()); u != "" {
+		fields = append(fields, field{"Release Notes", u})
+	}
+	for _, f := range customValues() {
+		if o.includeCustom(f.key) {
+			fields = append(fields, field{f.key, f.value()})
+		}
+	}
+	return fields
 }
 
 // ModuleInfo provides a pretty table with the modules and corresponding
@@ -127,11 +404,72 @@ func ModuleInfo() string {
 	return moduleInfo
 }
 
-// FullInfo provide a combined pretty printed information containing build info
-// as well as module info.
-func FullInfo() []byte {
-	bi := BasicInfo()
-	bi = append(bi, '\n')
-	bi = append(bi, moduleInfo...)
-	return bi
+// WriteModuleInfo writes ModuleInfo() to w.
+func WriteModuleInfo(w io.Writer) error {
+	_, err := io.WriteString(w, moduleInfo)
+	return err
+}
+
+// FullInfo provide a combined pretty printed information containing build info,
+// build settings as well as module info. Sections may be included or left out
+// using opts.
+func FullInfo(opts ...Option) []byte {
+	if w := newOptions(opts).w; w != nil {
+		_ = WriteFullInfo(w, opts...)
+		return nil
+	}
+	var b bytes.Buffer
+	_ = WriteFullInfo(&b, opts...)
+	return b.Bytes()
+}
+
+// WriteFullInfo writes FullInfo(opts...) to w.
+func WriteFullInfo(w io.Writer, opts ...Option) error {
+	o := newOptions(opts)
+	// The color is resolved against w, as the sections write to it directly.
+	basic := func(w io.Writer) error { return WriteBasicInfo(w, opts...) }
+	sections := []func(io.Writer) error{basic, WriteBuildSettingsInfo}
+	if o.runtime {
+		sections = append(sections, WriteRuntimeInfo)
+	}
+	if o.runtimeMetrics {
+		sections = append(sections, WriteRuntimeMetricsInfo)
+	}
+	if o.checksum {
+		sections = append(sections, writeExecutableChecksum)
+	}
+	if licenses != nil {
+		sections = append(sections, WriteLicenseInfo)
+	}
+	sections = append(sections, providedSections()...)
+	if !o.noModules {
+		sections = append(sections, WriteModuleInfo)
+	}
+	for i, section := range sections {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		if err := section(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stickyWriter remembers the first error returned by w, and fails all
+// subsequent writes with it.
+type stickyWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (s *stickyWriter) Write(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	n, err := s.w.Write(p)
+	s.err = err
+	return n, err
 }
@@ -0,0 +1,334 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: buildinfopb/buildinfo.proto
+
+package buildinfopb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Module is a dependency linked into the binary.
+type Module struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Path    string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Version string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Sum     string                 `protobuf:"bytes,3,opt,name=sum,proto3" json:"sum,omitempty"`
+	// Module replacing this one, if any.
+	Replace       *Module `protobuf:"bytes,4,opt,name=replace,proto3" json:"replace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Module) Reset() {
+	*x = Module{}
+	mi := &file_buildinfopb_buildinfo_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Module) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Module) ProtoMessage() {}
+
+func (x *Module) ProtoReflect() protoreflect.Message {
+	mi := &file_buildinfopb_buildinfo_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Module.ProtoReflect.Descriptor instead.
+func (*Module) Descriptor() ([]byte, []int) {
+	return file_buildinfopb_buildinfo_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Module) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Module) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Module) GetSum() string {
+	if x != nil {
+		return x.Sum
+	}
+	return ""
+}
+
+func (x *Module) GetReplace() *Module {
+	if x != nil {
+		return x.Replace
+	}
+	return nil
+}
+
+// BuildInfo describes a binary.
+type BuildInfo struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ReleaseVersion    string                 `protobuf:"bytes,1,opt,name=release_version,json=releaseVersion,proto3" json:"release_version,omitempty"`
+	BuildHash         string                 `protobuf:"bytes,2,opt,name=build_hash,json=buildHash,proto3" json:"build_hash,omitempty"`
+	BuildTime         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
+	BuildUrl          string                 `protobuf:"bytes,4,opt,name=build_url,json=buildUrl,proto3" json:"build_url,omitempty"`
+	GoVersion         string                 `protobuf:"bytes,5,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	StartupTime       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=startup_time,json=startupTime,proto3" json:"startup_time,omitempty"`
+	Modules           []*Module              `protobuf:"bytes,7,rep,name=modules,proto3" json:"modules,omitempty"`
+	Uptime            *durationpb.Duration   `protobuf:"bytes,8,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	Custom            map[string]string      `protobuf:"bytes,9,rep,name=custom,proto3" json:"custom,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	BuildBranch       string                 `protobuf:"bytes,10,opt,name=build_branch,json=buildBranch,proto3" json:"build_branch,omitempty"`
+	BuildTag          string                 `protobuf:"bytes,11,opt,name=build_tag,json=buildTag,proto3" json:"build_tag,omitempty"`
+	CommitSubject     string                 `protobuf:"bytes,12,opt,name=commit_subject,json=commitSubject,proto3" json:"commit_subject,omitempty"`
+	Dirty             bool                   `protobuf:"varint,13,opt,name=dirty,proto3" json:"dirty,omitempty"`
+	DeployEnvironment string                 `protobuf:"bytes,14,opt,name=deploy_environment,json=deployEnvironment,proto3" json:"deploy_environment,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BuildInfo) Reset() {
+	*x = BuildInfo{}
+	mi := &file_buildinfopb_buildinfo_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildInfo) ProtoMessage() {}
+
+func (x *BuildInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_buildinfopb_buildinfo_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildInfo.ProtoReflect.Descriptor instead.
+func (*BuildInfo) Descriptor() ([]byte, []int) {
+	return file_buildinfopb_buildinfo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BuildInfo) GetReleaseVersion() string {
+	if x != nil {
+		return x.ReleaseVersion
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetBuildHash() string {
+	if x != nil {
+		return x.BuildHash
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetBuildTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildTime
+	}
+	return nil
+}
+
+func (x *BuildInfo) GetBuildUrl() string {
+	if x != nil {
+		return x.BuildUrl
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetStartupTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartupTime
+	}
+	return nil
+}
+
+func (x *BuildInfo) GetModules() []*Module {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+func (x *BuildInfo) GetUptime() *durationpb.Duration {
+	if x != nil {
+		return x.Uptime
+	}
+	return nil
+}
+
+func (x *BuildInfo) GetCustom() map[string]string {
+	if x != nil {
+		return x.Custom
+	}
+	return nil
+}
+
+func (x *BuildInfo) GetBuildBranch() string {
+	if x != nil {
+		return x.BuildBranch
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetBuildTag() string {
+	if x != nil {
+		return x.BuildTag
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetCommitSubject() string {
+	if x != nil {
+		return x.CommitSubject
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetDirty() bool {
+	if x != nil {
+		return x.Dirty
+	}
+	return false
+}
+
+func (x *BuildInfo) GetDeployEnvironment() string {
+	if x != nil {
+		return x.DeployEnvironment
+	}
+	return ""
+}
+
+var File_buildinfopb_buildinfo_proto protoreflect.FileDescriptor
+
+const file_buildinfopb_buildinfo_proto_rawDesc = "" +
+	"\n" +
+	"\x1bbuildinfopb/buildinfo.proto\x12\fbuildinfo.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"x\n" +
+	"\x06Module\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x10\n" +
+	"\x03sum\x18\x03 \x01(\tR\x03sum\x12.\n" +
+	"\areplace\x18\x04 \x01(\v2\x14.buildinfo.v1.ModuleR\areplace\"\x90\x05\n" +
+	"\tBuildInfo\x12'\n" +
+	"\x0frelease_version\x18\x01 \x01(\tR\x0ereleaseVersion\x12\x1d\n" +
+	"\n" +
+	"build_hash\x18\x02 \x01(\tR\tbuildHash\x129\n" +
+	"\n" +
+	"build_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tbuildTime\x12\x1b\n" +
+	"\tbuild_url\x18\x04 \x01(\tR\bbuildUrl\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x05 \x01(\tR\tgoVersion\x12=\n" +
+	"\fstartup_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vstartupTime\x12.\n" +
+	"\amodules\x18\a \x03(\v2\x14.buildinfo.v1.ModuleR\amodules\x121\n" +
+	"\x06uptime\x18\b \x01(\v2\x19.google.protobuf.DurationR\x06uptime\x12;\n" +
+	"\x06custom\x18\t \x03(\v2#.buildinfo.v1.BuildInfo.CustomEntryR\x06custom\x12!\n" +
+	"\fbuild_branch\x18\n" +
+	" \x01(\tR\vbuildBranch\x12\x1b\n" +
+	"\tbuild_tag\x18\v \x01(\tR\bbuildTag\x12%\n" +
+	"\x0ecommit_subject\x18\f \x01(\tR\rcommitSubject\x12\x14\n" +
+	"\x05dirty\x18\r \x01(\bR\x05dirty\x12-\n" +
+	"\x12deploy_environment\x18\x0e \x01(\tR\x11deployEnvironment\x1a9\n" +
+	"\vCustomEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B(Z&github.com/daaku/buildinfo/buildinfopbb\x06proto3"
+
+var (
+	file_buildinfopb_buildinfo_proto_rawDescOnce sync.Once
+	file_buildinfopb_buildinfo_proto_rawDescData []byte
+)
+
+func file_buildinfopb_buildinfo_proto_rawDescGZIP() []byte {
+	file_buildinfopb_buildinfo_proto_rawDescOnce.Do(func() {
+		file_buildinfopb_buildinfo_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_buildinfopb_buildinfo_proto_rawDesc), len(file_buildinfopb_buildinfo_proto_rawDesc)))
+	})
+	return file_buildinfopb_buildinfo_proto_rawDescData
+}
+
+var file_buildinfopb_buildinfo_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_buildinfopb_buildinfo_proto_goTypes = []any{
+	(*Module)(nil),                // 0: buildinfo.v1.Module
+	(*BuildInfo)(nil),             // 1: buildinfo.v1.BuildInfo
+	nil,                           // 2: buildinfo.v1.BuildInfo.CustomEntry
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 4: google.protobuf.Duration
+}
+var file_buildinfopb_buildinfo_proto_depIdxs = []int32{
+	0, // 0: buildinfo.v1.Module.replace:type_name -> buildinfo.v1.Module
+	3, // 1: buildinfo.v1.BuildInfo.build_time:type_name -> google.protobuf.Timestamp
+	3, // 2: buildinfo.v1.BuildInfo.startup_time:type_name -> google.protobuf.Timestamp
+	0, // 3: buildinfo.v1.BuildInfo.modules:type_name -> buildinfo.v1.Module
+	4, // 4: buildinfo.v1.BuildInfo.uptime:type_name -> google.protobuf.Duration
+	2, // 5: buildinfo.v1.BuildInfo.custom:type_name -> buildinfo.v1.BuildInfo.CustomEntry
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_buildinfopb_buildinfo_proto_init() }
+func file_buildinfopb_buildinfo_proto_init() {
+	if File_buildinfopb_buildinfo_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_buildinfopb_buildinfo_proto_rawDesc), len(file_buildinfopb_buildinfo_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_buildinfopb_buildinfo_proto_goTypes,
+		DependencyIndexes: file_buildinfopb_buildinfo_proto_depIdxs,
+		MessageInfos:      file_buildinfopb_buildinfo_proto_msgTypes,
+	}.Build()
+	File_buildinfopb_buildinfo_proto = out.File
+	file_buildinfopb_buildinfo_proto_goTypes = nil
+	file_buildinfopb_buildinfo_proto_depIdxs = nil
+}
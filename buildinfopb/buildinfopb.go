@@ -0,0 +1,57 @@
+// Package buildinfopb provides the BuildInfo protocol buffer message, so
+// services exchanging build information over protobuf share a single
+// definition. The message is in the buildinfo.v1 package, alongside the
+// service provided by the grpcreflect package.
+package buildinfopb
+
+//go:generate protoc -I .. --go_out=.. --go_opt=paths=source_relative buildinfopb/buildinfo.proto
+
+import (
+	_ "embed"
+
+	"github.com/daaku/buildinfo"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Source is the source of the proto definition for the message.
+//
+//go:embed buildinfo.proto
+var Source string
+
+// Proto returns the build information of this binary as a BuildInfo message.
+func Proto() *BuildInfo {
+	info := buildinfo.Get()
+	pb := &BuildInfo{
+		ReleaseVersion:    info.ReleaseVersion,
+		BuildHash:         info.BuildHash,
+		BuildTime:         timestamppb.New(info.BuildTime),
+		BuildUrl:          info.BuildURL,
+		GoVersion:         info.GoVersion,
+		StartupTime:       timestamppb.New(info.StartupTime),
+		Uptime:            durationpb.New(info.Uptime),
+		Custom:            info.Custom,
+		BuildBranch:       buildinfo.BuildBranch(),
+		BuildTag:          buildinfo.BuildTag(),
+		CommitSubject:     buildinfo.CommitSubject(),
+		Dirty:             buildinfo.Dirty(),
+		DeployEnvironment: buildinfo.DeployEnvironment(),
+	}
+	for _, m := range info.Modules {
+		pb.Modules = append(pb.Modules, NewModule(m))
+	}
+	return pb
+}
+
+// NewModule converts m to a Module message.
+func NewModule(m buildinfo.Module) *Module {
+	pm := &Module{
+		Path:    m.Path,
+		Version: m.Version,
+		Sum:     m.Sum,
+	}
+	if m.Replace != nil {
+		pm.Replace = NewModule(*m.Replace)
+	}
+	return pm
+}
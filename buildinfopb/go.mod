@@ -0,0 +1,8 @@
+module github.com/daaku/buildinfo/buildinfopb
+
+go 1.23
+
+require (
+	github.com/daaku/buildinfo v0.1.0
+	google.golang.org/protobuf v1.36.11
+)
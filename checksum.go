@@ -0,0 +1,53 @@
+package buildinfo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+var (
+	checksumOnce sync.Once
+	checksum     string
+	checksumErr  error
+)
+
+// ExecutableChecksum returns the hex encoded SHA-256 digest of the running
+// executable, as found at os.Executable(). It is computed on first use and
+// cached for the lifetime of the process.
+func ExecutableChecksum() (string, error) {
+	checksumOnce.Do(func() {
+		checksum, checksumErr = hashExecutable()
+	})
+	return checksum, checksumErr
+}
+
+func hashExecutable() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("buildinfo: locating executable: %w", err)
+	}
+	f, err := os.Open(exe)
+	if err != nil {
+		return "", fmt.Errorf("buildinfo: opening executable: %w", err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("buildinfo: hashing executable %q: %w", exe, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeExecutableChecksum writes the ExecutableChecksum() section to w.
+func writeExecutableChecksum(w io.Writer) error {
+	sum, err := ExecutableChecksum()
+	if err != nil {
+		sum = "unavailable: " + err.Error()
+	}
+	_, err = fmt.Fprintf(w, "Executable SHA-256: %s\n", sum)
+	return err
+}
@@ -0,0 +1,91 @@
+package buildinfo
+
+import (
+	"net/url"
+	"strings"
+)
+
+// These may be provided via ldflags to describe the CI run that produced the
+// build, when BuildURL() does not or cannot describe it.
+var (
+	ciProvider   = ""
+	ciPipelineID = ""
+	ciJobID      = ""
+	ciRunner     = ""
+)
+
+// CIInfo describes the CI run that produced this binary.
+type CIInfo struct {
+	// Provider is the CI system, such as "github", "gitlab" or "buildkite".
+	Provider string
+
+	// PipelineID identifies the run, such as the GitHub Actions run ID, the
+	// GitLab pipeline ID or the Buildkite build number.
+	PipelineID string
+
+	// JobID identifies the job within the run.
+	JobID string
+
+	// Runner is the machine the job ran on.
+	Runner string
+
+	// URL is the BuildURL().
+	URL string
+}
+
+// CI returns the CI metadata for this build. The values provided via ldflags
+// take precedence, and the rest are parsed from BuildURL() on a best-effort
+// basis for GitHub Actions, GitLab and Buildkite URLs.
+func CI() CIInfo {
+	ci := parseCIURL(buildURL)
+	ci.URL = buildURL
+	if ciProvider != "" {
+		ci.Provider = ciProvider
+	}
+	if ciPipelineID != "" {
+		ci.PipelineID = ciPipelineID
+	}
+	if ciJobID != "" {
+		ci.JobID = ciJobID
+	}
+	ci.Runner = ciRunner
+	return ci
+}
+
+func parseCIURL(buildURL string) CIInfo {
+	u, err := url.Parse(buildURL)
+	if err != nil || u.Host == "" {
+		return CIInfo{}
+	}
+	path := strings.Trim(u.Path, "/")
+
+	// GitLab: https://gitlab.com/group/project/-/pipelines/123 or
+	// https://gitlab.com/group/project/-/jobs/456
+	if i := strings.Index(path, "/-/"); i > 0 {
+		parts := strings.Split(path[i+3:], "/")
+		if len(parts) >= 2 {
+			switch parts[0] {
+			case "pipelines":
+				return CIInfo{Provider: "gitlab", PipelineID: parts[1]}
+			case "jobs":
+				return CIInfo{Provider: "gitlab", JobID: parts[1]}
+			}
+		}
+		return CIInfo{}
+	}
+
+	parts := strings.Split(path, "/")
+	switch {
+	// GitHub Actions: https://github.com/owner/repo/actions/runs/123/job/456
+	case len(parts) >= 5 && parts[2] == "actions" && parts[3] == "runs":
+		ci := CIInfo{Provider: "github", PipelineID: parts[4]}
+		if len(parts) >= 7 && parts[5] == "job" {
+			ci.JobID = parts[6]
+		}
+		return ci
+	// Buildkite: https://buildkite.com/org/pipeline/builds/123#job-id
+	case u.Host == "buildkite.com" && len(parts) >= 4 && parts[2] == "builds":
+		return CIInfo{Provider: "buildkite", PipelineID: parts[3], JobID: u.Fragment}
+	}
+	return CIInfo{}
+}
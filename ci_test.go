@@ -0,0 +1,54 @@
+package buildinfo
+
+import "testing"
+
+func TestParseCIURL(t *testing.T) {
+	cases := []struct {
+		in   string
+		want CIInfo
+	}{
+		{
+			in:   "https://github.com/owner/repo/actions/runs/123",
+			want: CIInfo{Provider: "github", PipelineID: "123"},
+		},
+		{
+			in:   "https://github.com/owner/repo/actions/runs/123/job/456",
+			want: CIInfo{Provider: "github", PipelineID: "123", JobID: "456"},
+		},
+		{
+			in:   "https://github.example.com/owner/repo/actions/runs/123/attempts/2",
+			want: CIInfo{Provider: "github", PipelineID: "123"},
+		},
+		{
+			in:   "https://gitlab.com/group/subgroup/project/-/pipelines/789",
+			want: CIInfo{Provider: "gitlab", PipelineID: "789"},
+		},
+		{
+			in:   "https://gitlab.example.com/group/project/-/jobs/42/",
+			want: CIInfo{Provider: "gitlab", JobID: "42"},
+		},
+		{
+			in:   "https://buildkite.com/org/pipeline/builds/17#0190-abcd",
+			want: CIInfo{Provider: "buildkite", PipelineID: "17", JobID: "0190-abcd"},
+		},
+		{
+			in:   "https://buildkite.com/org/pipeline/builds/17",
+			want: CIInfo{Provider: "buildkite", PipelineID: "17"},
+		},
+		{in: ""},
+		{in: "not a url"},
+		{in: "/owner/repo/actions/runs/123"},
+		{in: "https://github.com/owner/repo"},
+		{in: "https://github.com/owner/repo/actions/runs"},
+		{in: "https://gitlab.com/group/project/-/merge_requests/1"},
+		{in: "https://gitlab.com/group/project/-/pipelines"},
+		{in: "https://example.com/org/pipeline/builds/17"},
+		{in: "https://ci.example.com/job/app/123/"},
+		{in: "http://[::1"},
+	}
+	for _, c := range cases {
+		if got := parseCIURL(c.in); got != c.want {
+			t.Errorf("parseCIURL(%q) = %+v, want %+v", c.in, got, c.want)
+		}
+	}
+}
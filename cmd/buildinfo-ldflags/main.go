@@ -0,0 +1,62 @@
+// Command buildinfo-ldflags prints the -ldflags that inject the build
+// information for the git repository in the current directory into the
+// buildinfo package. It is meant to be used as:
+//
+//	go build -ldflags "$(go run github.com/daaku/buildinfo/cmd/buildinfo-ldflags)"
+//
+// With -format docker-args it instead prints the arguments setting the OCI
+// image labels for the same build:
+//
+//	docker build $(go run github.com/daaku/buildinfo/cmd/buildinfo-ldflags -format docker-args) .
+//
+// With -format licenses it instead prints the license manifest of the modules
+// built by the packages given as arguments, "./..." by default, to be
+// embedded and read using buildinfo.LicensesFromEmbed:
+//
+//	go run github.com/daaku/buildinfo/cmd/buildinfo-ldflags -format licenses > licenses.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/daaku/buildinfo/ldflags"
+)
+
+func main() {
+	dir := flag.String("C", ".", "the git repository to inspect")
+	format := flag.String("format", "ldflags", "the output format, ldflags, docker-args or licenses")
+	flag.Parse()
+
+	if *format == "licenses" {
+		manifest, err := ldflags.Licenses(*dir, flag.Args()...)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		out, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(string(out))
+		return
+	}
+
+	v, err := ldflags.Detect(*dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	switch *format {
+	case "ldflags":
+		fmt.Println(v)
+	case "docker-args":
+		fmt.Println(v.DockerArgs())
+	default:
+		fmt.Fprintf(os.Stderr, "buildinfo-ldflags: unknown format %q\n", *format)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,66 @@
+// Package cmd provides ready made version commands for popular CLI
+// frameworks, so every CLI prints the build information the same way.
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/daaku/buildinfo"
+	"github.com/spf13/cobra"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	name  = "version"
+	usage = "Print version information"
+)
+
+// Cobra returns a cobra command named version.
+func Cobra() *cobra.Command {
+	var asJSON, modules bool
+	c := &cobra.Command{
+		Use:   name,
+		Short: usage,
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return write(c.OutOrStdout(), asJSON, modules)
+		},
+	}
+	c.Flags().BoolVar(&asJSON, "json", false, "print as JSON")
+	c.Flags().BoolVar(&modules, "modules", false, "include the module versions")
+	return c
+}
+
+// Urfave returns a urfave/cli command named version.
+func Urfave() *cli.Command {
+	return &cli.Command{
+		Name:  name,
+		Usage: usage,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "json", Usage: "print as JSON"},
+			&cli.BoolFlag{Name: "modules", Usage: "include the module versions"},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			return write(c.Root().Writer, c.Bool("json"), c.Bool("modules"))
+		},
+	}
+}
+
+func write(w io.Writer, asJSON, modules bool) error {
+	if asJSON {
+		info := buildinfo.Get()
+		if !modules {
+			info.Modules = nil
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+	color := buildinfo.WithColor(buildinfo.ColorAuto)
+	if modules {
+		return buildinfo.WriteFullInfo(w, color)
+	}
+	return buildinfo.WriteBasicInfo(w, color)
+}
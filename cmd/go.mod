@@ -0,0 +1,14 @@
+module github.com/daaku/buildinfo/cmd
+
+go 1.22
+
+require (
+	github.com/daaku/buildinfo v0.1.0
+	github.com/spf13/cobra v1.10.2
+	github.com/urfave/cli/v3 v3.13.0
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.9 // indirect
+)
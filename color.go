@@ -0,0 +1,53 @@
+package buildinfo
+
+import (
+	"io"
+	"os"
+)
+
+// ColorMode controls the use of ANSI colors in the output.
+type ColorMode int
+
+const (
+	// ColorNever disables colors. It is the default.
+	ColorNever ColorMode = iota
+
+	// ColorAuto enables colors when writing to a terminal, unless the NO_COLOR
+	// environment variable is set.
+	ColorAuto
+
+	// ColorAlways enables colors regardless of the output.
+	ColorAlways
+)
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiDim   = "\x1b[2m"
+	ansiCyan  = "\x1b[36m"
+)
+
+// WithColor colorizes the labels as well as the release version and build
+// hash, as configured by mode.
+func WithColor(mode ColorMode) Option {
+	return func(o *options) { o.color = mode }
+}
+
+// useColor returns true if the output written to w should be colorized.
+func (mode ColorMode) useColor(w io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorAuto:
+		if _, ok := os.LookupEnv("NO_COLOR"); ok {
+			return false
+		}
+		f, ok := w.(*os.File)
+		if !ok {
+			return false
+		}
+		fi, err := f.Stat()
+		return err == nil && fi.Mode()&os.ModeCharDevice != 0
+	}
+	return false
+}
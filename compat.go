@@ -0,0 +1,59 @@
+package buildinfo
+
+import "fmt"
+
+// Compatibility is the requirement a peer's version must satisfy for
+// CheckPeer.
+type Compatibility int
+
+const (
+	// SameMajor requires the same major version. As semantic versioning
+	// makes no promises before v1, v0 versions also require the same minor
+	// version.
+	SameMajor Compatibility = iota + 1
+
+	// SameMinor requires the same major and minor version.
+	SameMinor
+
+	// SameVersion requires the same version, ignoring build metadata.
+	SameVersion
+)
+
+// CompatibilityToken returns the version to send to peers during a handshake,
+// for them to check using CheckPeer.
+func CompatibilityToken() string {
+	return displayVersion()
+}
+
+// CheckPeer returns an error if theirVersion, as returned by the peer's
+// CompatibilityToken, is not compatible with ReleaseVersion() as required by
+// c. Versions that are not semantic versions are only compatible if both are
+// "dev".
+func CheckPeer(theirVersion string, c Compatibility) error {
+	if releaseVersion == "dev" && theirVersion == "dev" {
+		return nil
+	}
+	ours, ok := parseSemver(releaseVersion)
+	if !ok {
+		return fmt.Errorf("buildinfo: version %q is not a semantic version", releaseVersion)
+	}
+	theirs, ok := parseSemver(theirVersion)
+	if !ok {
+		return fmt.Errorf("buildinfo: peer version %q is not a semantic version", theirVersion)
+	}
+	var compatible bool
+	switch c {
+	case SameMajor:
+		compatible = ours.major == theirs.major && (ours.major != 0 || ours.minor == theirs.minor)
+	case SameMinor:
+		compatible = ours.major == theirs.major && ours.minor == theirs.minor
+	case SameVersion:
+		compatible = ours.compare(theirs) == 0
+	default:
+		return fmt.Errorf("buildinfo: unknown compatibility %d", c)
+	}
+	if !compatible {
+		return fmt.Errorf("buildinfo: peer version %s is incompatible with %s", theirVersion, displayVersion())
+	}
+	return nil
+}
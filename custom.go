@@ -0,0 +1,37 @@
+package buildinfo
+
+import "sync"
+
+type customField struct {
+	key   string
+	value func() string
+}
+
+var (
+	customFieldsMu sync.Mutex
+	customFields   []customField
+)
+
+// Register adds a custom field that is included in BasicInfo(), FullInfo()
+// and Get(). This is useful for additional values injected via ldflags, such
+// as the deploy environment. Registering an existing key replaces it.
+func Register(key string, value func() string) {
+	customFieldsMu.Lock()
+	defer customFieldsMu.Unlock()
+	for i, f := range customFields {
+		if f.key == key {
+			customFields[i].value = value
+			return
+		}
+	}
+	customFields = append(customFields, customField{key: key, value: value})
+}
+
+// customValues returns the current values of the custom fields, in
+// registration order.
+func customValues() []customField {
+	customFieldsMu.Lock()
+	fields := append([]customField(nil), customFields...)
+	customFieldsMu.Unlock()
+	return fields
+}
@@ -0,0 +1,31 @@
+package debughttp
+
+import (
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/daaku/buildinfo"
+)
+
+var publishExpvarOnce sync.Once
+
+// PublishExpvar publishes the build information as the "buildinfo" expvar map,
+// making it visible at /debug/vars. It is safe to call more than once.
+func PublishExpvar() {
+	publishExpvarOnce.Do(func() {
+		m := expvar.NewMap("buildinfo")
+		m.Set("build_hash", stringVar(buildinfo.BuildHash()))
+		m.Set("release_version", stringVar(buildinfo.ReleaseVersion()))
+		m.Set("build_time", stringVar(buildinfo.BuildTime().UTC().Format(time.RFC3339)))
+		m.Set("uptime_seconds", expvar.Func(func() interface{} {
+			return int64(time.Since(buildinfo.StartupTime()) / time.Second)
+		}))
+	})
+}
+
+func stringVar(s string) *expvar.String {
+	v := new(expvar.String)
+	v.Set(s)
+	return v
+}
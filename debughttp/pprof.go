@@ -0,0 +1,74 @@
+// Package debughttp provides HTTP handlers that colocate build information
+// with the standard debugging endpoints.
+//
+// Note that importing this package imports net/http/pprof and expvar, which
+// register their handlers on http.DefaultServeMux.
+package debughttp
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/pprof"
+	runtimepprof "runtime/pprof"
+	"strings"
+
+	"github.com/daaku/buildinfo"
+)
+
+// RegisterDebugPprof registers the standard net/http/pprof handlers under
+// prefix+"/pprof/", along with prefix+"/pprof/buildinfo" serving
+// buildinfo.BasicInfo().
+// The index page at prefix+"/pprof/" lists all the handlers.
+func RegisterDebugPprof(mux *http.ServeMux, prefix string) {
+	handlePprof(mux, strings.TrimSuffix(prefix, "/")+"/pprof/")
+}
+
+func handlePprof(mux *http.ServeMux, base string) {
+	mux.Handle(base, noCache(http.StripPrefix(base, http.HandlerFunc(servePprof))))
+}
+
+func noCache(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache")
+		h.ServeHTTP(w, r)
+	})
+}
+
+func servePprof(w http.ResponseWriter, r *http.Request) {
+	switch name := r.URL.Path; name {
+	case "":
+		servePprofIndex(w, r)
+	case "buildinfo":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_ = buildinfo.WriteBasicInfo(w)
+	case "cmdline":
+		pprof.Cmdline(w, r)
+	case "profile":
+		pprof.Profile(w, r)
+	case "symbol":
+		pprof.Symbol(w, r)
+	case "trace":
+		pprof.Trace(w, r)
+	default:
+		if runtimepprof.Lookup(name) == nil {
+			http.NotFound(w, r)
+			return
+		}
+		pprof.Handler(name).ServeHTTP(w, r)
+	}
+}
+
+func servePprofIndex(w http.ResponseWriter, r *http.Request) {
+	names := []string{"buildinfo", "cmdline", "profile", "symbol", "trace"}
+	for _, p := range runtimepprof.Profiles() {
+		names = append(names, p.Name())
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, "<html><head><title>pprof</title></head><body><ul>\n")
+	for _, name := range names {
+		name = html.EscapeString(name)
+		fmt.Fprintf(w, "<li><a href=\"%s\">%s</a></li>\n", name, name)
+	}
+	fmt.Fprint(w, "</ul></body></html>\n")
+}
@@ -0,0 +1,89 @@
+package debughttp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/daaku/buildinfo"
+)
+
+const debugServerTimeout = 5 * time.Second
+
+var (
+	debugServerMu   sync.Mutex
+	debugServerAddr net.Addr
+)
+
+// StartDebugServer starts a dedicated HTTP server on addr, separate from the
+// application's own mux, serving:
+//
+//	/_version  buildinfo.BasicInfo()
+//	/_health   a plain "ok"
+//	/_modules  buildinfo.ModuleInfo()
+//	/_pprof/   the same handlers as RegisterDebugPprof
+//
+// If addr is "", a random port on localhost is used. The server has 5 second
+// read and write timeouts, so CPU profiles and traces must be requested with
+// a shorter duration using the seconds parameter. Calling stop closes the
+// server. Only one debug server may be running at a time.
+func StartDebugServer(addr string) (actualAddr net.Addr, stop func(), err error) {
+	debugServerMu.Lock()
+	defer debugServerMu.Unlock()
+	if debugServerAddr != nil {
+		return nil, nil, errors.New("debughttp: debug server already started")
+	}
+
+	if addr == "" {
+		addr = "localhost:0"
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_version", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_ = buildinfo.WriteBasicInfo(w)
+	})
+	mux.HandleFunc("/_health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, "ok\n")
+	})
+	mux.HandleFunc("/_modules", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, buildinfo.ModuleInfo())
+	})
+	handlePprof(mux, "/_pprof/")
+
+	srv := &http.Server{
+		Handler:      mux,
+		ReadTimeout:  debugServerTimeout,
+		WriteTimeout: debugServerTimeout,
+	}
+	go func() { _ = srv.Serve(l) }()
+
+	debugServerAddr = l.Addr()
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			_ = srv.Close()
+			debugServerMu.Lock()
+			debugServerAddr = nil
+			debugServerMu.Unlock()
+		})
+	}
+	return debugServerAddr, stop, nil
+}
+
+// DebugServerAddr returns the address of the running debug server, or nil if
+// it has not been started.
+func DebugServerAddr() net.Addr {
+	debugServerMu.Lock()
+	defer debugServerMu.Unlock()
+	return debugServerAddr
+}
@@ -0,0 +1,173 @@
+package buildinfo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// InfoDiff describes the changes between two builds, as returned by Diff. It
+// encodes to JSON with snake_case keys, omitting what did not change.
+type InfoDiff struct {
+	ReleaseVersion *Change `json:"release_version,omitempty"`
+	BuildHash      *Change `json:"build_hash,omitempty"`
+	GoVersion      *Change `json:"go_version,omitempty"`
+
+	Added      []Module       `json:"added,omitempty"`
+	Removed    []Module       `json:"removed,omitempty"`
+	Upgraded   []ModuleChange `json:"upgraded,omitempty"`
+	Downgraded []ModuleChange `json:"downgraded,omitempty"`
+}
+
+// Change is a value that differs between two builds.
+type Change struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ModuleChange is a module whose version differs between two builds.
+type ModuleChange struct {
+	Path string `json:"path"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Diff reports what changed going from build a to build b. Modules are matched
+// by path, and the version of the replacement is used for replaced modules.
+// Versions that are not semantic versions are reported as upgrades when they
+// differ.
+func Diff(a, b Info) InfoDiff {
+	var d InfoDiff
+	d.ReleaseVersion = change(a.ReleaseVersion, b.ReleaseVersion)
+	d.BuildHash = change(a.BuildHash, b.BuildHash)
+	d.GoVersion = change(a.GoVersion, b.GoVersion)
+
+	before := make(map[string]Module, len(a.Modules))
+	for _, m := range a.Modules {
+		before[m.Path] = m
+	}
+	after := make(map[string]Module, len(b.Modules))
+	for _, m := range b.Modules {
+		after[m.Path] = m
+		old, ok := before[m.Path]
+		if !ok {
+			d.Added = append(d.Added, m)
+			continue
+		}
+		from, to := effectiveVersion(old), effectiveVersion(m)
+		if from == to {
+			continue
+		}
+		mc := ModuleChange{Path: m.Path, From: from, To: to}
+		fv, fok := parseSemver(from)
+		tv, tok := parseSemver(to)
+		if fok && tok && tv.compare(fv) < 0 {
+			d.Downgraded = append(d.Downgraded, mc)
+		} else {
+			d.Upgraded = append(d.Upgraded, mc)
+		}
+	}
+	for _, m := range a.Modules {
+		if _, ok := after[m.Path]; !ok {
+			d.Removed = append(d.Removed, m)
+		}
+	}
+
+	sortModules(d.Added)
+	sortModules(d.Removed)
+	sortModuleChanges(d.Upgraded)
+	sortModuleChanges(d.Downgraded)
+	return d
+}
+
+func change(from, to string) *Change {
+	if from == to {
+		return nil
+	}
+	return &Change{From: from, To: to}
+}
+
+func effectiveVersion(m Module) string {
+	if m.Replace != nil && m.Replace.Version != "" {
+		return m.Replace.Version
+	}
+	return m.Version
+}
+
+func sortModules(mods []Module) {
+	sort.Slice(mods, func(i, j int) bool { return mods[i].Path < mods[j].Path })
+}
+
+func sortModuleChanges(mods []ModuleChange) {
+	sort.Slice(mods, func(i, j int) bool { return mods[i].Path < mods[j].Path })
+}
+
+// Empty returns true if there are no differences.
+func (d InfoDiff) Empty() bool {
+	return d.ReleaseVersion == nil && d.BuildHash == nil && d.GoVersion == nil &&
+		len(d.Added) == 0 && len(d.Removed) == 0 &&
+		len(d.Upgraded) == 0 && len(d.Downgraded) == 0
+}
+
+// String returns a pretty-print version of the differences.
+func (d InfoDiff) String() string {
+	var b bytes.Buffer
+	_ = d.Write(&b)
+	return b.String()
+}
+
+// Write writes d.String() to w.
+func (d InfoDiff) Write(w io.Writer) error {
+	sw := &stickyWriter{w: w}
+	if d.Empty() {
+		fmt.Fprint(sw, "No changes\n")
+		return sw.err
+	}
+	tw := tabwriter.NewWriter(sw, 0, 0, 1, ' ', 0)
+	for _, c := range []struct {
+		name   string
+		change *Change
+	}{
+		{"Release Version", d.ReleaseVersion},
+		{"Build Hash", d.BuildHash},
+		{"Go Version", d.GoVersion},
+	} {
+		if c.change != nil {
+			fmt.Fprintf(tw, "%s:\t%s => %s\n", c.name, c.change.From, c.change.To)
+		}
+	}
+	for _, s := range []struct {
+		name string
+		mods []Module
+	}{
+		{"Added", d.Added},
+		{"Removed", d.Removed},
+	} {
+		if len(s.mods) == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "%s:\n", s.name)
+		for _, m := range s.mods {
+			fmt.Fprintf(tw, "  %s\t%s\n", m.Path, effectiveVersion(m))
+		}
+	}
+	for _, s := range []struct {
+		name string
+		mods []ModuleChange
+	}{
+		{"Upgraded", d.Upgraded},
+		{"Downgraded", d.Downgraded},
+	} {
+		if len(s.mods) == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "%s:\n", s.name)
+		for _, m := range s.mods {
+			fmt.Fprintf(tw, "  %s\t%s => %s\n", m.Path, m.From, m.To)
+		}
+	}
+	_ = tw.Flush()
+	return sw.err
+}
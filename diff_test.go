@@ -0,0 +1,107 @@
+package buildinfo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	base := Info{
+		ReleaseVersion: "v1.0.0",
+		BuildHash:      "aaa",
+		GoVersion:      "go1.22.0",
+		Modules: []Module{
+			{Path: "example.com/a", Version: "v1.0.0"},
+			{Path: "example.com/b", Version: "v1.2.0"},
+			{Path: "example.com/c", Version: "v0.1.0", Replace: &Module{Path: "example.com/fork", Version: "v0.2.0"}},
+		},
+	}
+	with := func(f func(*Info)) Info {
+		i := base
+		i.Modules = append([]Module{}, base.Modules...)
+		f(&i)
+		return i
+	}
+	cases := []struct {
+		name string
+		b    Info
+		want InfoDiff
+	}{
+		{name: "identical", b: base},
+		{
+			name: "versions",
+			b: with(func(i *Info) {
+				i.ReleaseVersion, i.BuildHash, i.GoVersion = "v1.1.0", "bbb", "go1.23.0"
+			}),
+			want: InfoDiff{
+				ReleaseVersion: &Change{From: "v1.0.0", To: "v1.1.0"},
+				BuildHash:      &Change{From: "aaa", To: "bbb"},
+				GoVersion:      &Change{From: "go1.22.0", To: "go1.23.0"},
+			},
+		},
+		{
+			name: "added",
+			b: with(func(i *Info) {
+				i.Modules = append(i.Modules, Module{Path: "example.com/e", Version: "v1.0.0"}, Module{Path: "example.com/d", Version: "v2.0.0"})
+			}),
+			want: InfoDiff{Added: []Module{{Path: "example.com/d", Version: "v2.0.0"}, {Path: "example.com/e", Version: "v1.0.0"}}},
+		},
+		{
+			name: "removed",
+			b:    with(func(i *Info) { i.Modules = i.Modules[1:] }),
+			want: InfoDiff{Removed: []Module{{Path: "example.com/a", Version: "v1.0.0"}}},
+		},
+		{
+			name: "upgraded",
+			b:    with(func(i *Info) { i.Modules[0] = Module{Path: "example.com/a", Version: "v1.0.1"} }),
+			want: InfoDiff{Upgraded: []ModuleChange{{Path: "example.com/a", From: "v1.0.0", To: "v1.0.1"}}},
+		},
+		{
+			name: "downgraded",
+			b:    with(func(i *Info) { i.Modules[1] = Module{Path: "example.com/b", Version: "v1.1.9"} }),
+			want: InfoDiff{Downgraded: []ModuleChange{{Path: "example.com/b", From: "v1.2.0", To: "v1.1.9"}}},
+		},
+		{
+			name: "not semver",
+			b:    with(func(i *Info) { i.Modules[0] = Module{Path: "example.com/a", Version: "(devel)"} }),
+			want: InfoDiff{Upgraded: []ModuleChange{{Path: "example.com/a", From: "v1.0.0", To: "(devel)"}}},
+		},
+		{
+			name: "replacement downgraded",
+			b: with(func(i *Info) {
+				i.Modules[2] = Module{Path: "example.com/c", Version: "v0.1.0", Replace: &Module{Path: "example.com/fork", Version: "v0.1.5"}}
+			}),
+			want: InfoDiff{Downgraded: []ModuleChange{{Path: "example.com/c", From: "v0.2.0", To: "v0.1.5"}}},
+		},
+		{
+			name: "replacement removed",
+			b:    with(func(i *Info) { i.Modules[2] = Module{Path: "example.com/c", Version: "v0.1.0"} }),
+			want: InfoDiff{Downgraded: []ModuleChange{{Path: "example.com/c", From: "v0.2.0", To: "v0.1.0"}}},
+		},
+		{
+			name: "replacement added",
+			b: with(func(i *Info) {
+				i.Modules[0] = Module{Path: "example.com/a", Version: "v1.0.0", Replace: &Module{Path: "example.com/a2", Version: "v1.3.0"}}
+			}),
+			want: InfoDiff{Upgraded: []ModuleChange{{Path: "example.com/a", From: "v1.0.0", To: "v1.3.0"}}},
+		},
+		{
+			name: "replaced by directory",
+			b: with(func(i *Info) {
+				i.Modules[0] = Module{Path: "example.com/a", Version: "v1.0.0", Replace: &Module{Path: "../a"}}
+			}),
+		},
+	}
+	for _, c := range cases {
+		got := Diff(base, c.b)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %+v, want %+v", c.name, got, c.want)
+		}
+		if got.Empty() != reflect.DeepEqual(c.want, InfoDiff{}) {
+			t.Errorf("%s: got Empty() %v", c.name, got.Empty())
+		}
+	}
+	if got := Diff(base, base).String(); got != "No changes\n" {
+		t.Errorf("identical: got %q, want %q", got, "No changes\n")
+	}
+}
@@ -0,0 +1,109 @@
+package buildinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// injectable are the variables that may be provided via ldflags or FromEmbed,
+// keyed by their name in an embedded file.
+var injectable = map[string]struct {
+	v    *string
+	dflt string
+}{
+	"release_version":    {&releaseVersion, "dev"},
+	"build_hash":         {&buildHash, "dev"},
+	"build_time":         {&buildTimeUnix, "0"},
+	"build_url":          {&buildURL, ""},
+	"build_branch":       {&buildBranch, ""},
+	"build_tag":          {&buildTag, ""},
+	"commit_subject":     {&commitSubject, ""},
+	"build_dirty":        {&buildDirty, ""},
+	"is_retracted":       {&isRetracted, "false"},
+	"deprecated_message": {&deprecatedMessage, ""},
+	"ci_provider":        {&ciProvider, ""},
+	"ci_pipeline_id":     {&ciPipelineID, ""},
+	"ci_job_id":          {&ciJobID, ""},
+	"ci_runner":          {&ciRunner, ""},
+	"deploy_environment": {&deployEnvironment, ""},
+	"direct_modules":     {&directModules, ""},
+}
+
+// injected are the values provided via ldflags, before any fallbacks were
+// applied.
+var injected map[string]string
+
+// embedded are the values read by FromEmbed.
+var embedded map[string]string
+
+func injectedValues() map[string]string {
+	values := make(map[string]string, len(injectable))
+	for name, i := range injectable {
+		values[name] = *i.v
+	}
+	return values
+}
+
+// FromEmbed reads the build information from the JSON file name in fsys, for
+// build systems where passing ldflags is awkward. It is meant to be used with
+// a file embedded using go:embed:
+//
+//	//go:embed buildinfo.json
+//	var buildinfoFS embed.FS
+//
+//	func init() {
+//		if err := buildinfo.FromEmbed(buildinfoFS, "buildinfo.json"); err != nil {
+//			panic(err)
+//		}
+//	}
+//
+// The file contains an object with any of the keys release_version,
+// build_hash, build_time, build_url, build_branch, build_tag, commit_subject,
+// build_dirty, is_retracted, deprecated_message, ci_provider, ci_pipeline_id,
+// ci_job_id, ci_runner, deploy_environment and direct_modules. Values provided via ldflags
+// take precedence over the embedded ones, which in turn take precedence over
+// the information stamped by the go command. It must be called during program
+// initialization, before the build information is used concurrently.
+func FromEmbed(fsys fs.FS, name string) error {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("buildinfo: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var raw map[string]any
+	if err := dec.Decode(&raw); err != nil {
+		return fmt.Errorf("buildinfo: invalid %s: %w", name, err)
+	}
+
+	values := make(map[string]string, len(raw))
+	var unknown []string
+	for key, value := range raw {
+		if _, ok := injectable[key]; !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		switch value := value.(type) {
+		case string:
+			values[key] = value
+		case json.Number:
+			values[key] = value.String()
+		case bool:
+			values[key] = fmt.Sprint(value)
+		default:
+			return fmt.Errorf("buildinfo: invalid %s: unexpected value for %q", name, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("buildinfo: invalid %s: unknown keys %s", name, strings.Join(unknown, ", "))
+	}
+
+	embedded = values
+	derive()
+	return nil
+}
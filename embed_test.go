@@ -0,0 +1,129 @@
+package buildinfo
+
+import (
+	"os"
+	"runtime/debug"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// saveDerived restores the inputs of derive, and the values derived from them,
+// when the test completes.
+func saveDerived(t *testing.T) {
+	savedInjected, savedEmbedded, savedOverrides, savedSettings := injected, embedded, overrides, goSettings
+	t.Cleanup(func() {
+		injected, embedded, overrides, goSettings = savedInjected, savedEmbedded, savedOverrides, savedSettings
+		derive()
+	})
+}
+
+func TestFromEmbed(t *testing.T) {
+	saveDerived(t)
+	fsys := fstest.MapFS{
+		"ok.json":      {Data: []byte(`{"release_version": "v1.2.3", "build_time": 1700000000, "build_dirty": true}`)},
+		"unknown.json": {Data: []byte(`{"release_version": "v1.2.3", "nope": "x", "also": "y"}`)},
+		"object.json":  {Data: []byte(`{"release_version": {}}`)},
+		"bad.json":     {Data: []byte(`[`)},
+	}
+	cases := []struct {
+		name    string
+		wantErr string
+	}{
+		{name: "missing.json", wantErr: "buildinfo: open missing.json"},
+		{name: "bad.json", wantErr: "buildinfo: invalid bad.json"},
+		{name: "object.json", wantErr: `buildinfo: invalid object.json: unexpected value for "release_version"`},
+		{name: "unknown.json", wantErr: "buildinfo: invalid unknown.json: unknown keys also, nope"},
+	}
+	for _, c := range cases {
+		err := FromEmbed(fsys, c.name)
+		if err == nil || !strings.HasPrefix(err.Error(), c.wantErr) {
+			t.Errorf("%s: got error %v, want %q", c.name, err, c.wantErr)
+		}
+	}
+
+	injected = map[string]string{}
+	for key, i := range injectable {
+		injected[key] = i.dflt
+	}
+	overrides = nil
+	if err := FromEmbed(fsys, "ok.json"); err != nil {
+		t.Fatal(err)
+	}
+	if releaseVersion != "v1.2.3" {
+		t.Errorf("got release version %q, want v1.2.3", releaseVersion)
+	}
+	if got := BuildTime().Unix(); got != 1700000000 {
+		t.Errorf("got build time %d, want 1700000000", got)
+	}
+	if !dirty {
+		t.Error("got clean build, want dirty from build_dirty")
+	}
+}
+
+func TestDerivePrecedence(t *testing.T) {
+	saveDerived(t)
+	vcs := []debug.BuildSetting{{Key: "vcs.revision", Value: "0123456789abcdef"}}
+	cases := []struct {
+		name     string
+		ldflags  string
+		embedded string
+		override string
+		settings []debug.BuildSetting
+		wantHash string
+	}{
+		{name: "default", wantHash: "dev"},
+		{name: "vcs", settings: vcs, wantHash: "0123456789ab"},
+		{name: "embed over vcs", embedded: "embedded", settings: vcs, wantHash: "embedded"},
+		{name: "ldflags over embed", ldflags: "ldflags", embedded: "embedded", settings: vcs, wantHash: "ldflags"},
+		{name: "env over ldflags", ldflags: "ldflags", embedded: "embedded", override: "env", settings: vcs, wantHash: "env"},
+		{name: "env over vcs", override: "env", settings: vcs, wantHash: "env"},
+	}
+	for _, c := range cases {
+		injected = map[string]string{}
+		for key, i := range injectable {
+			injected[key] = i.dflt
+		}
+		embedded, overrides = nil, nil
+		if c.ldflags != "" {
+			injected["build_hash"] = c.ldflags
+		}
+		if c.embedded != "" {
+			embedded = map[string]string{"build_hash": c.embedded}
+		}
+		if c.override != "" {
+			overrides = map[string]string{"build_hash": c.override}
+		}
+		goSettings = c.settings
+		derive()
+		if buildHash != c.wantHash {
+			t.Errorf("%s: got build hash %q, want %q", c.name, buildHash, c.wantHash)
+		}
+	}
+}
+
+func TestApplyEnvOverridesRebuilds(t *testing.T) {
+	saveDerived(t)
+	injected = map[string]string{}
+	for key, i := range injectable {
+		injected[key] = i.dflt
+	}
+	embedded, goSettings = nil, nil
+
+	t.Setenv("BUILDINFO_RELEASE_VERSION", "v9.9.9")
+	ApplyEnvOverrides()
+	if releaseVersion != "v9.9.9" {
+		t.Errorf("got release version %q, want v9.9.9", releaseVersion)
+	}
+
+	t.Setenv("BUILDINFO_RELEASE_VERSION", "")
+	os.Unsetenv("BUILDINFO_RELEASE_VERSION")
+	t.Setenv("BUILDINFO_BUILD_HASH", "abc")
+	ApplyEnvOverrides()
+	if _, ok := overrides["release_version"]; ok {
+		t.Errorf("got stale release version override %q", overrides["release_version"])
+	}
+	if buildHash != "abc" {
+		t.Errorf("got build hash %q, want abc", buildHash)
+	}
+}
@@ -0,0 +1,106 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// EncodeYAML writes the Info to w as a YAML document, using the same keys as
+// the JSON encoding.
+func (i Info) EncodeYAML(w io.Writer) error {
+	e := i.encoded()
+	sw := &stickyWriter{w: w}
+	fmt.Fprintf(sw, "release_version: %s\n", quote(e.ReleaseVersion))
+	fmt.Fprintf(sw, "build_hash: %s\n", quote(e.BuildHash))
+	fmt.Fprintf(sw, "build_time: %s\n", quote(e.BuildTime))
+	if e.BuildURL != "" {
+		fmt.Fprintf(sw, "build_url: %s\n", quote(e.BuildURL))
+	}
+	fmt.Fprintf(sw, "go_version: %s\n", quote(e.GoVersion))
+	fmt.Fprintf(sw, "startup_time: %s\n", quote(e.StartupTime))
+	fmt.Fprintf(sw, "uptime_seconds: %d\n", e.UptimeSeconds)
+	if len(e.Modules) > 0 {
+		fmt.Fprint(sw, "modules:\n")
+		for _, m := range e.Modules {
+			fmt.Fprintf(sw, "  - path: %s\n    version: %s\n", quote(m.Path), quote(m.Version))
+			if m.Sum != "" {
+				fmt.Fprintf(sw, "    sum: %s\n", quote(m.Sum))
+			}
+			if r := m.Replace; r != nil {
+				fmt.Fprintf(sw, "    replace:\n      path: %s\n      version: %s\n", quote(r.Path), quote(r.Version))
+				if r.Sum != "" {
+					fmt.Fprintf(sw, "      sum: %s\n", quote(r.Sum))
+				}
+			}
+		}
+	}
+	if len(e.Custom) > 0 {
+		fmt.Fprint(sw, "custom:\n")
+		for _, k := range sortedKeys(e.Custom) {
+			fmt.Fprintf(sw, "  %s: %s\n", quote(k), quote(e.Custom[k]))
+		}
+	}
+	if m := e.RuntimeMetrics; m != nil {
+		fmt.Fprintf(sw, "runtime_metrics:\n  heap_in_use_bytes: %d\n  total_bytes: %d\n  gc_cycles: %d\n  goroutines: %d\n",
+			m.HeapInUseBytes, m.TotalBytes, m.GCCycles, m.Goroutines)
+	}
+	return sw.err
+}
+
+// EncodeTOML writes the Info to w as a TOML document, using the same keys as
+// the JSON encoding.
+func (i Info) EncodeTOML(w io.Writer) error {
+	e := i.encoded()
+	sw := &stickyWriter{w: w}
+	fmt.Fprintf(sw, "release_version = %s\n", quote(e.ReleaseVersion))
+	fmt.Fprintf(sw, "build_hash = %s\n", quote(e.BuildHash))
+	fmt.Fprintf(sw, "build_time = %s\n", e.BuildTime)
+	if e.BuildURL != "" {
+		fmt.Fprintf(sw, "build_url = %s\n", quote(e.BuildURL))
+	}
+	fmt.Fprintf(sw, "go_version = %s\n", quote(e.GoVersion))
+	fmt.Fprintf(sw, "startup_time = %s\n", e.StartupTime)
+	fmt.Fprintf(sw, "uptime_seconds = %d\n", e.UptimeSeconds)
+	if len(e.Custom) > 0 {
+		fmt.Fprint(sw, "\n[custom]\n")
+		for _, k := range sortedKeys(e.Custom) {
+			fmt.Fprintf(sw, "%s = %s\n", quote(k), quote(e.Custom[k]))
+		}
+	}
+	if m := e.RuntimeMetrics; m != nil {
+		fmt.Fprintf(sw, "\n[runtime_metrics]\nheap_in_use_bytes = %d\ntotal_bytes = %d\ngc_cycles = %d\ngoroutines = %d\n",
+			m.HeapInUseBytes, m.TotalBytes, m.GCCycles, m.Goroutines)
+	}
+	for _, m := range e.Modules {
+		fmt.Fprintf(sw, "\n[[modules]]\npath = %s\nversion = %s\n", quote(m.Path), quote(m.Version))
+		if m.Sum != "" {
+			fmt.Fprintf(sw, "sum = %s\n", quote(m.Sum))
+		}
+		if r := m.Replace; r != nil {
+			fmt.Fprintf(sw, "replace = { path = %s, version = %s", quote(r.Path), quote(r.Version))
+			if r.Sum != "" {
+				fmt.Fprintf(sw, ", sum = %s", quote(r.Sum))
+			}
+			fmt.Fprint(sw, " }\n")
+		}
+	}
+	return sw.err
+}
+
+// quote returns s as a double quoted string. The JSON escapes are valid in
+// both YAML and TOML.
+func quote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,64 @@
+package buildinfo
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// envOverridable are the keys of the injectable variables that may be
+// overridden by ApplyEnvOverrides.
+var envOverridable = []string{
+	"release_version",
+	"build_hash",
+	"build_time",
+	"build_url",
+	"deploy_environment",
+}
+
+// overrides are the values set by ApplyEnvOverrides, which take precedence
+// over all others.
+var overrides map[string]string
+
+// ApplyEnvOverrides overrides the release version, build hash, build time,
+// build URL and deploy environment with the BUILDINFO_RELEASE_VERSION,
+// BUILDINFO_BUILD_HASH, BUILDINFO_BUILD_TIME, BUILDINFO_BUILD_URL and
+// BUILDINFO_DEPLOY_ENVIRONMENT environment variables, when they are set. This
+// allows a platform that injects the deployment identity via the environment
+// to have it reflected alongside the compile time information, and child
+// processes to inherit the identity set using Environ. Importing the
+// envoverride package calls it during initialization. It must be called
+// before the build information is used concurrently.
+func ApplyEnvOverrides() {
+	overrides = make(map[string]string, len(envOverridable))
+	for _, key := range envOverridable {
+		if value, ok := os.LookupEnv("BUILDINFO_" + strings.ToUpper(key)); ok {
+			overrides[key] = value
+		}
+	}
+	derive()
+}
+
+// Environ returns the build identity as BUILDINFO_* environment variables in
+// the "key=value" form, to be passed to child processes that call
+// ApplyEnvOverrides so they report the same identity as this process. Values
+// that are not available are left out.
+func Environ() []string {
+	env := make([]string, 0, len(envOverridable))
+	for _, key := range envOverridable {
+		if i := injectable[key]; *i.v != i.dflt {
+			env = append(env, "BUILDINFO_"+strings.ToUpper(key)+"="+*i.v)
+		}
+	}
+	return env
+}
+
+// SetCmdEnv appends Environ() to the environment of cmd. If cmd.Env is nil,
+// the environment of the current process is used as the base, matching the
+// default behavior of exec.Cmd.
+func SetCmdEnv(cmd *exec.Cmd) {
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, Environ()...)
+}
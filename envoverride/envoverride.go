@@ -0,0 +1,14 @@
+// Package envoverride applies the BUILDINFO_* environment variable overrides
+// when imported, so they are reflected by the buildinfo package from the
+// start of the program:
+//
+//	import _ "github.com/daaku/buildinfo/envoverride"
+//
+// See buildinfo.ApplyEnvOverrides for the supported variables.
+package envoverride
+
+import "github.com/daaku/buildinfo"
+
+func init() {
+	buildinfo.ApplyEnvOverrides()
+}
@@ -0,0 +1,49 @@
+package buildinfo
+
+import (
+	"runtime"
+	"strings"
+)
+
+// SentryRelease returns the release identifier in the form expected by Sentry
+// and similar crash reporters, such as:
+//
+//	myapp@1.4.2+abc1234
+//
+// A leading "v" is removed from semantic versions, and the build hash is added
+// as build metadata unless the version already has some.
+func SentryRelease(appName string) string {
+	version := releaseVersion
+	if _, ok := parseSemver(version); ok {
+		version = strings.TrimPrefix(version, "v")
+	}
+	if buildHash != "dev" && !strings.Contains(version, "+") {
+		version += "+" + buildHash
+	}
+	return appName + "@" + version
+}
+
+// SentryDist returns the distribution identifier for Sentry, which
+// distinguishes builds of the same release. It is the build hash.
+func SentryDist() string {
+	return buildHash
+}
+
+// ErrorReportingTags returns the build information as tags for error
+// reporters, with the keys release, version, build_hash, go_version and, if
+// available, build_time and environment.
+func ErrorReportingTags(appName string) map[string]string {
+	tags := map[string]string{
+		"release":    SentryRelease(appName),
+		"version":    releaseVersion,
+		"build_hash": buildHash,
+		"go_version": runtime.Version(),
+	}
+	if buildTimeUnix != "0" {
+		tags["build_time"] = buildTimeUnix
+	}
+	if deployEnvironment != "" {
+		tags["environment"] = deployEnvironment
+	}
+	return tags
+}
@@ -0,0 +1,122 @@
+package buildinfo
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type feature struct {
+	bounded      bool
+	dflt         bool
+	enabledFrom  *semver
+	disabledFrom *semver
+}
+
+// FeatureRegistry tracks features whose state is derived from the release
+// version of this binary. The zero value is ready to use, and it is safe for
+// concurrent use.
+type FeatureRegistry struct {
+	mu       sync.RWMutex
+	features map[string]feature
+}
+
+var defaultRegistry FeatureRegistry
+
+// DefaultRegistry returns the package level FeatureRegistry.
+func DefaultRegistry() *FeatureRegistry {
+	return &defaultRegistry
+}
+
+// Register registers a feature that is enabled when ReleaseVersion() is >=
+// enabledFrom and < disabledFrom. Either bound may be empty to leave that side
+// unbounded. Features are always disabled when ReleaseVersion() is not a
+// semantic version, which includes "dev" builds. It panics if a non-empty
+// bound is not a semantic version.
+func (r *FeatureRegistry) Register(name, enabledFrom, disabledFrom string) {
+	f := feature{
+		bounded:      true,
+		enabledFrom:  mustParseBound(name, enabledFrom),
+		disabledFrom: mustParseBound(name, disabledFrom),
+	}
+	r.set(name, f)
+}
+
+// RegisterDefault registers a feature with no version bounds, which is always
+// in the dflt state.
+func (r *FeatureRegistry) RegisterDefault(name string, dflt bool) {
+	r.set(name, feature{dflt: dflt})
+}
+
+func (r *FeatureRegistry) set(name string, f feature) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.features == nil {
+		r.features = make(map[string]feature)
+	}
+	r.features[name] = f
+}
+
+func mustParseBound(name, bound string) *semver {
+	if bound == "" {
+		return nil
+	}
+	v, ok := parseSemver(bound)
+	if !ok {
+		panic(fmt.Sprintf("buildinfo: invalid version %q for %q", bound, name))
+	}
+	return &v
+}
+
+// Enabled returns true if the named feature is enabled. Unknown features are
+// disabled.
+func (r *FeatureRegistry) Enabled(name string) bool {
+	r.mu.RLock()
+	f, ok := r.features[name]
+	r.mu.RUnlock()
+	return ok && f.enabled()
+}
+
+func (f feature) enabled() bool {
+	if !f.bounded {
+		return f.dflt
+	}
+	v, ok := parseSemver(ReleaseVersion())
+	if !ok {
+		return false
+	}
+	if f.enabledFrom != nil && v.compare(*f.enabledFrom) < 0 {
+		return false
+	}
+	if f.disabledFrom != nil && v.compare(*f.disabledFrom) >= 0 {
+		return false
+	}
+	return true
+}
+
+// All returns the sorted names of the features that are currently in the
+// active state.
+func (r *FeatureRegistry) All(active bool) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var names []string
+	for name, f := range r.features {
+		if f.enabled() == active {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RegisteredFeatures returns the sorted names of all registered features.
+func (r *FeatureRegistry) RegisteredFeatures() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.features))
+	for name := range r.features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
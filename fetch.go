@@ -0,0 +1,122 @@
+package buildinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFetchConcurrency = 10
+	fetchInitialBackoff     = 100 * time.Millisecond
+	fetchMaxBackoff         = 10 * time.Second
+)
+
+// FetchAllOptions configures FetchAll.
+type FetchAllOptions struct {
+	// Concurrency is the maximum number of URLs fetched at once. It defaults
+	// to 10.
+	Concurrency int
+
+	// Timeout bounds each attempt. It defaults to no timeout beyond that of
+	// the context.
+	Timeout time.Duration
+
+	// RetryCount is the number of times a fetch failing with a network error,
+	// a 429 or a 5xx status is retried, with exponential backoff of up to ten
+	// seconds.
+	RetryCount int
+}
+
+// FetchResult is the outcome of fetching the build information from a URL.
+type FetchResult struct {
+	URL   string
+	Info  Info
+	Error error
+
+	// Latency is the duration of the final attempt.
+	Latency time.Duration
+}
+
+// FetchAll fetches the JSON build information from each of the urls. The
+// results are in the same order as the urls. If ctx expires, the results for
+// the URLs that did not complete contain the context error.
+func FetchAll(ctx context.Context, urls []string, opts FetchAllOptions) []FetchResult {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultFetchConcurrency
+	}
+
+	results := make([]FetchResult, len(urls))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, url := range urls {
+		results[i].URL = url
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Error = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(r *FetchResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fetchWithRetry(ctx, r, opts)
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+func fetchWithRetry(ctx context.Context, r *FetchResult, opts FetchAllOptions) {
+	backoff := fetchInitialBackoff
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		var retry bool
+		r.Info, retry, r.Error = fetchOne(ctx, r.URL, opts.Timeout)
+		r.Latency = time.Since(start)
+		if !retry || attempt >= opts.RetryCount {
+			return
+		}
+		t := time.NewTimer(backoff)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			r.Error = ctx.Err()
+			return
+		}
+		backoff = min(2*backoff, fetchMaxBackoff)
+	}
+}
+
+func fetchOne(ctx context.Context, url string, timeout time.Duration) (info Info, retry bool, err error) {
+	parent := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Info{}, false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return Info{}, parent.Err() == nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		retry = res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+		return Info{}, retry, fmt.Errorf("buildinfo: unexpected status %s from %s", res.Status, url)
+	}
+	if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
+		return Info{}, false, fmt.Errorf("buildinfo: invalid response from %s: %w", url, err)
+	}
+	return info, false, nil
+}
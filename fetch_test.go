@@ -0,0 +1,141 @@
+package buildinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func serveInfo(w http.ResponseWriter, version string) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(Info{ReleaseVersion: version})
+}
+
+func TestFetchAllOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Answer the earlier URLs last, so completion order differs from the
+		// order of the urls.
+		if r.URL.Path == "/a" {
+			time.Sleep(20 * time.Millisecond)
+		}
+		serveInfo(w, r.URL.Path[1:])
+	}))
+	defer srv.Close()
+
+	urls := []string{srv.URL + "/a", srv.URL + "/b", srv.URL + "/c"}
+	results := FetchAll(context.Background(), urls, FetchAllOptions{})
+	if len(results) != len(urls) {
+		t.Fatalf("got %d results, want %d", len(results), len(urls))
+	}
+	for i, r := range results {
+		if r.URL != urls[i] {
+			t.Errorf("result %d: got URL %q, want %q", i, r.URL, urls[i])
+		}
+		if r.Error != nil {
+			t.Errorf("result %d: unexpected error %v", i, r.Error)
+		}
+		if want := urls[i][len(srv.URL)+1:]; r.Info.ReleaseVersion != want {
+			t.Errorf("result %d: got release version %q, want %q", i, r.Info.ReleaseVersion, want)
+		}
+	}
+}
+
+func TestFetchAllConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		serveInfo(w, "v1")
+	}))
+	defer srv.Close()
+
+	urls := make([]string, 10)
+	for i := range urls {
+		urls[i] = srv.URL
+	}
+	for _, r := range FetchAll(context.Background(), urls, FetchAllOptions{Concurrency: 3}) {
+		if r.Error != nil {
+			t.Errorf("unexpected error %v", r.Error)
+		}
+	}
+	if maxInFlight > 3 {
+		t.Errorf("got %d concurrent fetches, want at most 3", maxInFlight)
+	}
+}
+
+func TestFetchAllRetries(t *testing.T) {
+	cases := []struct {
+		name      string
+		statuses  []int
+		retries   int
+		wantErr   bool
+		wantCalls int32
+		minDelay  time.Duration
+	}{
+		{name: "ok", statuses: []int{200}, wantCalls: 1},
+		{name: "server error retried", statuses: []int{500, 503, 200}, retries: 2, wantCalls: 3, minDelay: 3 * fetchInitialBackoff},
+		{name: "too many requests retried", statuses: []int{429, 200}, retries: 1, wantCalls: 2, minDelay: fetchInitialBackoff},
+		{name: "retries exhausted", statuses: []int{500, 500, 500}, retries: 1, wantErr: true, wantCalls: 2},
+		{name: "not found not retried", statuses: []int{404, 200}, retries: 2, wantErr: true, wantCalls: 1},
+		{name: "bad request not retried", statuses: []int{400, 200}, retries: 2, wantErr: true, wantCalls: 1},
+	}
+	for _, c := range cases {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status := c.statuses[calls.Add(1)-1]
+			if status != http.StatusOK {
+				w.WriteHeader(status)
+				return
+			}
+			serveInfo(w, "v1")
+		}))
+		start := time.Now()
+		r := FetchAll(context.Background(), []string{srv.URL}, FetchAllOptions{RetryCount: c.retries})[0]
+		elapsed := time.Since(start)
+		srv.Close()
+		if (r.Error != nil) != c.wantErr {
+			t.Errorf("%s: got error %v, want error %v", c.name, r.Error, c.wantErr)
+		}
+		if got := calls.Load(); got != c.wantCalls {
+			t.Errorf("%s: got %d requests, want %d", c.name, got, c.wantCalls)
+		}
+		if elapsed < c.minDelay {
+			t.Errorf("%s: finished after %v, want a backoff of at least %v", c.name, elapsed, c.minDelay)
+		}
+	}
+}
+
+func TestFetchAllContextExpired(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			<-r.Context().Done()
+			return
+		}
+		serveInfo(w, "v1")
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	urls := []string{srv.URL + "/fast", srv.URL + "/slow"}
+	results := FetchAll(ctx, urls, FetchAllOptions{RetryCount: 3})
+	if results[0].Error != nil || results[0].Info.ReleaseVersion != "v1" {
+		t.Errorf("fast: got %v, %v, want release version v1", results[0].Info.ReleaseVersion, results[0].Error)
+	}
+	if !errors.Is(results[1].Error, context.DeadlineExceeded) {
+		t.Errorf("slow: got error %v, want %v", results[1].Error, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,33 @@
+package buildinfo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"runtime"
+)
+
+// Fingerprint returns a short deterministic hash identifying this build. It
+// covers the release version, build hash, build time, Go version and module
+// list, making it suitable as a key for caches that must be invalidated on
+// any new build.
+func Fingerprint() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "version\x00%s\x00", releaseVersion)
+	fmt.Fprintf(h, "hash\x00%s\x00", buildHash)
+	fmt.Fprintf(h, "time\x00%s\x00", buildTimeUnix)
+	fmt.Fprintf(h, "go\x00%s\x00", runtime.Version())
+	for _, m := range modules {
+		hashModule(h, m)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func hashModule(h hash.Hash, m Module) {
+	fmt.Fprintf(h, "module\x00%s\x00%s\x00%s\x00", m.Path, m.Version, m.Sum)
+	if m.Replace != nil {
+		fmt.Fprint(h, "replace\x00")
+		hashModule(h, *m.Replace)
+	}
+}
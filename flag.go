@@ -0,0 +1,32 @@
+package buildinfo
+
+import (
+	"flag"
+	"os"
+	"strconv"
+)
+
+// VersionFlag registers a -version flag on flag.CommandLine. When the flag is
+// set, FullInfo() is printed to stdout and the process exits. It must be
+// called before flag.Parse.
+func VersionFlag() {
+	flag.Var(versionFlag{}, "version", "print version information and exit")
+}
+
+type versionFlag struct{}
+
+func (versionFlag) IsBoolFlag() bool { return true }
+
+func (versionFlag) String() string { return "false" }
+
+func (versionFlag) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	if v {
+		_ = WriteFullInfo(os.Stdout)
+		os.Exit(0)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package buildinfo
+
+import (
+	"bytes"
+	"text/template"
+)
+
+// Format executes the text/template tmpl with Get() as its data. For example
+// a one line summary can be produced with:
+//
+//	{{.ReleaseVersion}} ({{.BuildHash}}) built {{.BuildTime.Format "2006-01-02"}}
+//
+// and the modules listed with:
+//
+//	{{range .Modules}}{{.Path}} {{.Version}}
+//	{{end}}
+func Format(tmpl string) ([]byte, error) {
+	t, err := template.New("buildinfo").Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, Get()); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,67 @@
+package buildinfo
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// minGoVersion may be set via ldflags, for example:
+//
+//	-X github.com/daaku/buildinfo.minGoVersion=1.22.3
+var minGoVersion = ""
+
+const goDownloadURL = "https://go.dev/dl/"
+
+// MinSupportedGoVersion returns the minimum Go runtime version this binary
+// supports. It may be blank if no minimum was set.
+func MinSupportedGoVersion() string {
+	return minGoVersion
+}
+
+// ValidateGoVersion returns an error if the Go runtime is older than
+// MinSupportedGoVersion(). Development versions of Go are always accepted.
+func ValidateGoVersion() error {
+	if minGoVersion == "" {
+		return nil
+	}
+	min, ok := parseGoVersion(minGoVersion)
+	if !ok {
+		return fmt.Errorf("buildinfo: invalid minimum go version %q", minGoVersion)
+	}
+	rt, ok := parseGoVersion(runtime.Version())
+	if !ok {
+		return nil
+	}
+	if rt.compare(min) < 0 {
+		return fmt.Errorf("buildinfo: go version %s is older than the minimum supported go%s",
+			runtime.Version(), strings.TrimPrefix(minGoVersion, "go"))
+	}
+	return nil
+}
+
+// FailOnUnsupportedGoVersion panics if ValidateGoVersion returns an error.
+func FailOnUnsupportedGoVersion() {
+	if err := ValidateGoVersion(); err != nil {
+		panic(fmt.Sprintf("%s, upgrade from %s", err, goDownloadURL))
+	}
+}
+
+// parseGoVersion parses versions such as "go1.21", "1.21.5" or "go1.22rc1".
+// Trailing details such as experiment flags are ignored.
+func parseGoVersion(s string) (semver, bool) {
+	if i := strings.IndexByte(s, ' '); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimPrefix(s, "go")
+	for _, pre := range []string{"rc", "beta"} {
+		if i := strings.Index(s, pre); i > 0 {
+			s = s[:i] + "-" + pre + "." + s[i+len(pre):]
+			break
+		}
+	}
+	if strings.HasPrefix(s, "v") {
+		return semver{}, false
+	}
+	return parseSemver(s)
+}
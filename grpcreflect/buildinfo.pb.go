@@ -0,0 +1,68 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: grpcreflect/buildinfo.proto
+
+package grpcreflect
+
+import (
+	buildinfopb "github.com/daaku/buildinfo/buildinfopb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_grpcreflect_buildinfo_proto protoreflect.FileDescriptor
+
+const file_grpcreflect_buildinfo_proto_rawDesc = "" +
+	"\n" +
+	"\x1bgrpcreflect/buildinfo.proto\x12\fbuildinfo.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1bbuildinfopb/buildinfo.proto2S\n" +
+	"\x10BuildInfoService\x12?\n" +
+	"\fGetBuildInfo\x12\x16.google.protobuf.Empty\x1a\x17.buildinfo.v1.BuildInfoB(Z&github.com/daaku/buildinfo/grpcreflectb\x06proto3"
+
+var file_grpcreflect_buildinfo_proto_goTypes = []any{
+	(*emptypb.Empty)(nil),         // 0: google.protobuf.Empty
+	(*buildinfopb.BuildInfo)(nil), // 1: buildinfo.v1.BuildInfo
+}
+var file_grpcreflect_buildinfo_proto_depIdxs = []int32{
+	0, // 0: buildinfo.v1.BuildInfoService.GetBuildInfo:input_type -> google.protobuf.Empty
+	1, // 1: buildinfo.v1.BuildInfoService.GetBuildInfo:output_type -> buildinfo.v1.BuildInfo
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_grpcreflect_buildinfo_proto_init() }
+func file_grpcreflect_buildinfo_proto_init() {
+	if File_grpcreflect_buildinfo_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_grpcreflect_buildinfo_proto_rawDesc), len(file_grpcreflect_buildinfo_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_grpcreflect_buildinfo_proto_goTypes,
+		DependencyIndexes: file_grpcreflect_buildinfo_proto_depIdxs,
+	}.Build()
+	File_grpcreflect_buildinfo_proto = out.File
+	file_grpcreflect_buildinfo_proto_goTypes = nil
+	file_grpcreflect_buildinfo_proto_depIdxs = nil
+}
@@ -0,0 +1,129 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: grpcreflect/buildinfo.proto
+
+package grpcreflect
+
+import (
+	context "context"
+	buildinfopb "github.com/daaku/buildinfo/buildinfopb"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BuildInfoService_GetBuildInfo_FullMethodName = "/buildinfo.v1.BuildInfoService/GetBuildInfo"
+)
+
+// BuildInfoServiceClient is the client API for BuildInfoService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BuildInfoService exposes the build information of the running binary.
+type BuildInfoServiceClient interface {
+	// GetBuildInfo returns the build information of the running binary.
+	GetBuildInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*buildinfopb.BuildInfo, error)
+}
+
+type buildInfoServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBuildInfoServiceClient(cc grpc.ClientConnInterface) BuildInfoServiceClient {
+	return &buildInfoServiceClient{cc}
+}
+
+func (c *buildInfoServiceClient) GetBuildInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*buildinfopb.BuildInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(buildinfopb.BuildInfo)
+	err := c.cc.Invoke(ctx, BuildInfoService_GetBuildInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BuildInfoServiceServer is the server API for BuildInfoService service.
+// All implementations must embed UnimplementedBuildInfoServiceServer
+// for forward compatibility.
+//
+// BuildInfoService exposes the build information of the running binary.
+type BuildInfoServiceServer interface {
+	// GetBuildInfo returns the build information of the running binary.
+	GetBuildInfo(context.Context, *emptypb.Empty) (*buildinfopb.BuildInfo, error)
+	mustEmbedUnimplementedBuildInfoServiceServer()
+}
+
+// UnimplementedBuildInfoServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBuildInfoServiceServer struct{}
+
+func (UnimplementedBuildInfoServiceServer) GetBuildInfo(context.Context, *emptypb.Empty) (*buildinfopb.BuildInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBuildInfo not implemented")
+}
+func (UnimplementedBuildInfoServiceServer) mustEmbedUnimplementedBuildInfoServiceServer() {}
+func (UnimplementedBuildInfoServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeBuildInfoServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BuildInfoServiceServer will
+// result in compilation errors.
+type UnsafeBuildInfoServiceServer interface {
+	mustEmbedUnimplementedBuildInfoServiceServer()
+}
+
+func RegisterBuildInfoServiceServer(s grpc.ServiceRegistrar, srv BuildInfoServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBuildInfoServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BuildInfoService_ServiceDesc, srv)
+}
+
+func _BuildInfoService_GetBuildInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildInfoServiceServer).GetBuildInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuildInfoService_GetBuildInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildInfoServiceServer).GetBuildInfo(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BuildInfoService_ServiceDesc is the grpc.ServiceDesc for BuildInfoService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BuildInfoService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "buildinfo.v1.BuildInfoService",
+	HandlerType: (*BuildInfoServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBuildInfo",
+			Handler:    _BuildInfoService_GetBuildInfo_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "grpcreflect/buildinfo.proto",
+}
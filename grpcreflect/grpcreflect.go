@@ -0,0 +1,62 @@
+// Package grpcreflect provides a gRPC service exposing build information,
+// discoverable via gRPC server reflection.
+//
+// Registering the service on a server is a single call:
+//
+//	grpcreflect.Register(grpcServer)
+//
+// Tools such as grpcurl can then call the service without any client side
+// proto files:
+//
+//	grpcurl -plaintext localhost:8080 buildinfo.v1.BuildInfoService/GetBuildInfo
+package grpcreflect
+
+//go:generate protoc -I .. --go_out=.. --go_opt=paths=source_relative --go-grpc_out=.. --go-grpc_opt=paths=source_relative grpcreflect/buildinfo.proto
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/daaku/buildinfo/buildinfopb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Proto is the source of the proto definition for the service. It imports the
+// definition of the BuildInfo message from buildinfopb.Source.
+//
+//go:embed buildinfo.proto
+var Proto string
+
+// BuildInfo describes the running binary, and Module a dependency linked into
+// it. They are defined by the buildinfopb package.
+type (
+	BuildInfo = buildinfopb.BuildInfo
+	Module    = buildinfopb.Module
+)
+
+const reflectionService = "grpc.reflection.v1.ServerReflection"
+
+// Register registers the BuildInfoService on s. It also registers the server
+// reflection service, unless it has already been registered.
+func Register(s *grpc.Server) {
+	RegisterBuildInfoServiceServer(s, NewServer())
+	if _, ok := s.GetServiceInfo()[reflectionService]; !ok {
+		reflection.Register(s)
+	}
+}
+
+// NewServer returns the implementation of the BuildInfoService, for use with
+// registrars other than *grpc.Server.
+func NewServer() BuildInfoServiceServer {
+	return server{}
+}
+
+type server struct {
+	UnimplementedBuildInfoServiceServer
+}
+
+func (server) GetBuildInfo(context.Context, *emptypb.Empty) (*BuildInfo, error) {
+	return buildinfopb.Proto(), nil
+}
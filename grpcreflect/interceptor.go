@@ -0,0 +1,21 @@
+package grpcreflect
+
+import (
+	"context"
+
+	"github.com/daaku/buildinfo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor returns an interceptor that adds the x-build-hash
+// and x-release-version header metadata to every response.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		_ = grpc.SetHeader(ctx, metadata.Pairs(
+			"x-build-hash", buildinfo.BuildHash(),
+			"x-release-version", buildinfo.ReleaseVersion(),
+		))
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,111 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Handler returns an http.Handler serving the build information. Requests
+// preferring application/json get Get() encoded as JSON, those preferring
+// text/html, such as from browsers, get HTML(opts...), and all others get
+// FullInfo(opts...). The preference follows the quality values in the Accept
+// header, and JSON and HTML are never served for a quality of 0.
+func Handler(opts ...Option) http.Handler {
+	o := newOptions(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Vary", "Accept")
+		switch negotiate(r) {
+		case "application/json":
+			info := Get()
+			if o.runtimeMetrics {
+				m := ReadRuntimeMetrics()
+				info.RuntimeMetrics = &m
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(info)
+		case "text/html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_ = WriteHTML(w, opts...)
+		default:
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_ = WriteFullInfo(w, opts...)
+		}
+	})
+}
+
+// offers are the media types served by Handler, in order of preference when
+// the client accepts several of them equally.
+var offers = []string{"text/plain", "application/json", "text/html"}
+
+// mediaRange is a media range from an Accept header, such as "text/*;q=0.5".
+type mediaRange struct {
+	typ, subtype string
+	q            float64
+}
+
+// negotiate returns the offer with the highest quality in the Accept header of
+// r, preferring the most specific match between offers of equal quality. It
+// falls back to text/plain if no offer is acceptable.
+func negotiate(r *http.Request) string {
+	ranges := parseAccept(r)
+	best, bestQ, bestSpecificity := "text/plain", 0.0, -1
+	for _, offer := range offers {
+		q, specificity := quality(ranges, offer)
+		if q > bestQ || (q > 0 && q == bestQ && specificity > bestSpecificity) {
+			best, bestQ, bestSpecificity = offer, q, specificity
+		}
+	}
+	return best
+}
+
+// parseAccept returns the media ranges in the Accept header of r, skipping
+// invalid ones.
+func parseAccept(r *http.Request) []mediaRange {
+	var ranges []mediaRange
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			mt, params, err := mime.ParseMediaType(part)
+			if err != nil {
+				continue
+			}
+			typ, subtype, ok := strings.Cut(mt, "/")
+			if !ok {
+				continue
+			}
+			q := 1.0
+			if v, ok := params["q"]; ok {
+				if q, err = strconv.ParseFloat(v, 64); err != nil || q < 0 || q > 1 {
+					continue
+				}
+			}
+			ranges = append(ranges, mediaRange{typ: typ, subtype: subtype, q: q})
+		}
+	}
+	return ranges
+}
+
+// quality returns the quality of the most specific range matching mediaType,
+// and how specific it is: 2 for an exact match, 1 for type/* and 0 for */*.
+// Without a matching range, it returns a quality of 0 and a specificity of -1.
+func quality(ranges []mediaRange, mediaType string) (q float64, specificity int) {
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+	specificity = -1
+	for _, r := range ranges {
+		s := -1
+		switch {
+		case r.typ == typ && r.subtype == subtype:
+			s = 2
+		case r.typ == typ && r.subtype == "*":
+			s = 1
+		case r.typ == "*" && r.subtype == "*":
+			s = 0
+		}
+		if s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+	return q, specificity
+}
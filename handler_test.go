@@ -0,0 +1,47 @@
+package buildinfo
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlerNegotiation(t *testing.T) {
+	cases := []struct {
+		accept []string
+		want   string
+	}{
+		{want: "text/plain"},
+		{accept: []string{"*/*"}, want: "text/plain"},
+		{accept: []string{"application/json"}, want: "application/json"},
+		{accept: []string{"text/html"}, want: "text/html"},
+		{accept: []string{"application/json, text/html"}, want: "application/json"},
+		{accept: []string{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"}, want: "text/html"},
+		{accept: []string{"application/json;q=0"}, want: "text/plain"},
+		{accept: []string{"text/html;q=0, */*"}, want: "text/plain"},
+		{accept: []string{"text/plain;q=1, application/json;q=0.1"}, want: "text/plain"},
+		{accept: []string{"text/plain;q=0.5, application/json;q=0.8"}, want: "application/json"},
+		{accept: []string{"text/*;q=0.3, text/html;q=0.7, application/json;q=0.5"}, want: "text/html"},
+		{accept: []string{"text/*, application/json;q=0.9"}, want: "text/plain"},
+		{accept: []string{"application/*;q=0.2, */*;q=0.1"}, want: "application/json"},
+		{accept: []string{"text/html;q=0.4", "application/json;q=0.6"}, want: "application/json"},
+		{accept: []string{"application/json;q=bogus, text/html"}, want: "text/html"},
+		{accept: []string{"image/png"}, want: "text/plain"},
+	}
+	h := Handler()
+	for _, c := range cases {
+		r := httptest.NewRequest("GET", "/", nil)
+		for _, a := range c.accept {
+			r.Header.Add("Accept", a)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		got, _, _ := strings.Cut(w.Header().Get("Content-Type"), ";")
+		if got != c.want {
+			t.Errorf("Accept %q: got %s, want %s", c.accept, got, c.want)
+		}
+		if v := w.Header().Get("Vary"); v != "Accept" {
+			t.Errorf("Accept %q: got Vary %q, want Accept", c.accept, v)
+		}
+	}
+}
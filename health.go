@@ -0,0 +1,54 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type healthCheckJSON struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+type healthJSON struct {
+	Status         string            `json:"status"`
+	ReleaseVersion string            `json:"release_version"`
+	BuildHash      string            `json:"build_hash"`
+	BuildTime      string            `json:"build_time"`
+	UptimeSeconds  int64             `json:"uptime_seconds"`
+	Checks         []healthCheckJSON `json:"checks,omitempty"`
+}
+
+// HealthHandler returns an http.Handler serving a JSON health payload that
+// includes the release version, build hash, build time and uptime alongside
+// the results of the checks, in order. It responds with 200 OK and a status
+// of "ok" if all checks return nil, and 503 Service Unavailable and a status
+// of "fail" otherwise.
+func HealthHandler(checks ...func() error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := healthJSON{
+			Status:         "ok",
+			ReleaseVersion: releaseVersion,
+			BuildHash:      buildHash,
+			BuildTime:      buildTime.UTC().Format(time.RFC3339),
+			UptimeSeconds:  int64(Uptime() / time.Second),
+		}
+		status := http.StatusOK
+		for _, check := range checks {
+			var c healthCheckJSON
+			if err := check(); err != nil {
+				c.Error = err.Error()
+				h.Status = "fail"
+				status = http.StatusServiceUnavailable
+			} else {
+				c.OK = true
+			}
+			h.Checks = append(h.Checks, c)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(h)
+	})
+}
@@ -0,0 +1,64 @@
+package buildinfo
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+)
+
+var htmlTemplate = template.Must(template.New("buildinfo").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Build Information</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { text-align: left; padding: 0.2em 1em 0.2em 0; vertical-align: top; }
+td { font-family: monospace; }
+.warning { color: #b00; font-weight: bold; }
+</style>
+</head>
+<body>
+{{- if .Retracted}}
+<p class="warning">WARNING: this version has been retracted</p>
+{{- end}}
+<table>
+{{- range .Fields}}
+<tr><th>{{.Name}}</th><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- if .Modules}}
+<details>
+<summary>Modules ({{len .Modules}})</summary>
+<table>
+{{- range .Modules}}
+<tr><td>{{.Path}}</td><td>{{.Version}}</td></tr>
+{{- end}}
+</table>
+</details>
+{{- end}}
+</body>
+</html>
+`))
+
+// HTML returns a self-contained HTML page with the BasicInfo() table and a
+// collapsible list of the modules, suitable for status pages.
+func HTML(opts ...Option) []byte {
+	var b bytes.Buffer
+	_ = WriteHTML(&b, opts...)
+	return b.Bytes()
+}
+
+// WriteHTML writes HTML(opts...) to w.
+func WriteHTML(w io.Writer, opts ...Option) error {
+	return htmlTemplate.Execute(w, struct {
+		Retracted bool
+		Fields    []field
+		Modules   []Module
+	}{
+		Retracted: retracted,
+		Fields:    basicFields(newOptions(opts)),
+		Modules:   redactModules(modules),
+	})
+}
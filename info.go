@@ -0,0 +1,132 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// Info is the structured form of the build information of a binary.
+type Info struct {
+	ReleaseVersion string
+	BuildHash      string
+	BuildTime      time.Time
+	BuildURL       string
+	GoVersion      string
+	StartupTime    time.Time
+	Uptime         time.Duration
+	Modules        []Module
+
+	// Custom contains the fields added with Register.
+	Custom map[string]string
+
+	// RuntimeMetrics is a snapshot of the state of the Go runtime, if it was
+	// requested using WithRuntimeMetrics.
+	RuntimeMetrics *RuntimeMetrics
+}
+
+// Module is a dependency linked into a binary.
+type Module struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	Sum     string `json:"sum,omitempty"`
+
+	// Replace is the module replacing this one, if any.
+	Replace *Module `json:"replace,omitempty"`
+}
+
+func newModule(m *debug.Module) Module {
+	mod := Module{Path: m.Path, Version: m.Version, Sum: m.Sum}
+	if m.Replace != nil {
+		r := newModule(m.Replace)
+		mod.Replace = &r
+	}
+	return mod
+}
+
+// Get returns the build information of this binary, with the values masked by
+// Redact.
+func Get() Info {
+	info := Info{
+		ReleaseVersion: releaseVersion,
+		BuildHash:      buildHash,
+		BuildTime:      buildTime,
+		BuildURL:       redactURL(buildURL),
+		GoVersion:      runtime.Version(),
+		StartupTime:    startupTime,
+		Uptime:         Uptime(),
+		Modules:        redactModules(modules),
+	}
+	if fields := customValues(); len(fields) > 0 {
+		info.Custom = make(map[string]string, len(fields))
+		for _, f := range fields {
+			info.Custom[f.key] = f.value()
+		}
+	}
+	return info
+}
+
+type infoJSON struct {
+	ReleaseVersion string            `json:"release_version"`
+	BuildHash      string            `json:"build_hash"`
+	BuildTime      string            `json:"build_time"`
+	BuildURL       string            `json:"build_url,omitempty"`
+	GoVersion      string            `json:"go_version"`
+	StartupTime    string            `json:"startup_time"`
+	UptimeSeconds  int64             `json:"uptime_seconds"`
+	Modules        []Module          `json:"modules,omitempty"`
+	Custom         map[string]string `json:"custom,omitempty"`
+	RuntimeMetrics *RuntimeMetrics   `json:"runtime_metrics,omitempty"`
+}
+
+// MarshalJSON encodes the Info in the format described by JSONSchema().
+func (i Info) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.encoded())
+}
+
+// encoded returns the Info in the form shared by the encoders.
+func (i Info) encoded() infoJSON {
+	return infoJSON{
+		ReleaseVersion: i.ReleaseVersion,
+		BuildHash:      i.BuildHash,
+		BuildTime:      i.BuildTime.UTC().Format(time.RFC3339),
+		BuildURL:       i.BuildURL,
+		GoVersion:      i.GoVersion,
+		StartupTime:    i.StartupTime.UTC().Format(time.RFC3339Nano),
+		UptimeSeconds:  int64(i.Uptime / time.Second),
+		Modules:        i.Modules,
+		Custom:         i.Custom,
+		RuntimeMetrics: i.RuntimeMetrics,
+	}
+}
+
+// UnmarshalJSON decodes an Info encoded by MarshalJSON.
+func (i *Info) UnmarshalJSON(data []byte) error {
+	var j infoJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*i = Info{
+		ReleaseVersion: j.ReleaseVersion,
+		BuildHash:      j.BuildHash,
+		BuildURL:       j.BuildURL,
+		GoVersion:      j.GoVersion,
+		Uptime:         time.Duration(j.UptimeSeconds) * time.Second,
+		Modules:        j.Modules,
+		Custom:         j.Custom,
+		RuntimeMetrics: j.RuntimeMetrics,
+	}
+	var err error
+	if j.BuildTime != "" {
+		if i.BuildTime, err = time.Parse(time.RFC3339, j.BuildTime); err != nil {
+			return err
+		}
+	}
+	if j.StartupTime != "" {
+		if i.StartupTime, err = time.Parse(time.RFC3339, j.StartupTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package buildinfo
+
+import "strings"
+
+// maxLabelValue is the maximum length of a Kubernetes label value.
+const maxLabelValue = 63
+
+// KubernetesLabels returns the build information as Kubernetes labels, with
+// the values sanitized to conform to the label value rules. It contains the
+// keys:
+//
+//	app.kubernetes.io/version  ReleaseVersion()
+//	buildinfo/hash             BuildHash()
+//	buildinfo/time             BuildTime() in Unix seconds, if available
+func KubernetesLabels() map[string]string {
+	labels := map[string]string{
+		"app.kubernetes.io/version": labelValue(releaseVersion),
+		"buildinfo/hash":            labelValue(buildHash),
+	}
+	if buildTimeUnix != "0" {
+		labels["buildinfo/time"] = buildTimeUnix
+	}
+	return labels
+}
+
+// labelValue sanitizes s to be a valid label value: at most 63 characters that
+// are alphanumeric, '-', '_' or '.', beginning and ending with an alphanumeric
+// character. Other characters are replaced with '-'.
+func labelValue(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if !isAlnum(c) && c != '-' && c != '_' && c != '.' {
+			b[i] = '-'
+		}
+	}
+	if len(b) > maxLabelValue {
+		b = b[:maxLabelValue]
+	}
+	return strings.TrimFunc(string(b), func(r rune) bool {
+		return !isAlnum(byte(r))
+	})
+}
+
+func isAlnum(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
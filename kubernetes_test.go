@@ -0,0 +1,33 @@
+package buildinfo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLabelValue(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{in: "v1.4.2", want: "v1.4.2"},
+		{in: "abc1234-dirty", want: "abc1234-dirty"},
+		{in: "feature/login", want: "feature-login"},
+		{in: "v1.0.0+build.5", want: "v1.0.0-build.5"},
+		{in: "-leading.and_trailing-", want: "leading.and_trailing"},
+		{in: "__", want: ""},
+		{in: "", want: ""},
+		{in: "café", want: "caf"},
+		{in: "ü1", want: "1"},
+		{in: strings.Repeat("a", 70), want: strings.Repeat("a", 63)},
+		{in: strings.Repeat("a", 62) + "-b", want: strings.Repeat("a", 62)},
+	}
+	for _, c := range cases {
+		got := labelValue(c.in)
+		if got != c.want {
+			t.Errorf("labelValue(%q) = %q, want %q", c.in, got, c.want)
+		}
+		if len(got) > maxLabelValue {
+			t.Errorf("labelValue(%q) is %d characters long", c.in, len(got))
+		}
+	}
+}
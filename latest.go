@@ -0,0 +1,82 @@
+package buildinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// LatestRelease is the result of comparing ReleaseVersion() against the newest
+// published release.
+type LatestRelease struct {
+	Current string
+	Latest  string
+
+	// URL links to the latest release, if the endpoint provided one.
+	URL string
+
+	// Outdated is true if Latest is newer than Current. It is always false if
+	// Current is not a semantic version, such as for "dev" builds.
+	Outdated bool
+}
+
+// CheckLatest fetches the newest published version from url and compares it
+// against ReleaseVersion(). The endpoint must respond with a JSON object
+// containing a "version" and optionally a "url" field.
+func CheckLatest(ctx context.Context, url string) (LatestRelease, error) {
+	return checkLatest(ctx, url, func(r io.Reader) (string, string, error) {
+		var v struct {
+			Version string `json:"version"`
+			URL     string `json:"url"`
+		}
+		err := json.NewDecoder(r).Decode(&v)
+		return v.Version, v.URL, err
+	})
+}
+
+// CheckLatestGitHub is like CheckLatest, but uses the latest release of the
+// GitHub repository owner/repo, whose tag is expected to be the version.
+func CheckLatestGitHub(ctx context.Context, owner, repo string) (LatestRelease, error) {
+	u := "https://api.github.com/repos/" + url.PathEscape(owner) + "/" +
+		url.PathEscape(repo) + "/releases/latest"
+	return checkLatest(ctx, u, func(r io.Reader) (string, string, error) {
+		var v struct {
+			TagName string `json:"tag_name"`
+			HTMLURL string `json:"html_url"`
+		}
+		err := json.NewDecoder(r).Decode(&v)
+		return v.TagName, v.HTMLURL, err
+	})
+}
+
+func checkLatest(ctx context.Context, url string, decode func(io.Reader) (string, string, error)) (LatestRelease, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return LatestRelease{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return LatestRelease{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return LatestRelease{}, fmt.Errorf("buildinfo: unexpected status %s from %s", res.Status, url)
+	}
+	latest, link, err := decode(res.Body)
+	if err != nil {
+		return LatestRelease{}, fmt.Errorf("buildinfo: invalid response from %s: %w", url, err)
+	}
+	latestV, ok := parseSemver(latest)
+	if !ok {
+		return LatestRelease{}, fmt.Errorf("buildinfo: invalid latest version %q from %s", latest, url)
+	}
+	r := LatestRelease{Current: releaseVersion, Latest: latest, URL: link}
+	if current, ok := parseSemver(releaseVersion); ok {
+		r.Outdated = latestV.compare(current) > 0
+	}
+	return r, nil
+}
@@ -0,0 +1,228 @@
+// Package ldflags generates the -ldflags used to inject build information into
+// the buildinfo package, from the local git repository and the environment of
+// common CI systems.
+package ldflags
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daaku/buildinfo"
+)
+
+const prefix = "github.com/daaku/buildinfo."
+
+// Values are the values injected into the buildinfo package. Blank values are
+// left out of the flags.
+type Values struct {
+	BuildTimeUnix  string
+	BuildHash      string
+	ReleaseVersion string
+	BuildURL       string
+	BuildBranch    string
+	BuildTag       string
+	CommitSubject  string
+	BuildDirty     string
+	CIProvider     string
+	CIPipelineID   string
+	CIJobID        string
+	CIRunner       string
+	DirectModules  string
+}
+
+// Detect inspects the git repository at dir and the environment to determine
+// the Values. GitHub Actions and GitLab CI are recognized from their
+// environment variables. The RELEASE_VERSION environment variable sets the
+// release version, which otherwise defaults to the tag of the commit, if any.
+// The build time is the current time, or SOURCE_DATE_EPOCH if set. The direct
+// modules are read from the go.mod file in dir, if any.
+func Detect(dir string) (Values, error) {
+	var v Values
+	var err error
+	if v.BuildHash, err = git(dir, "rev-parse", "--short", "HEAD"); err != nil {
+		return Values{}, err
+	}
+	if status, err := git(dir, "status", "--porcelain"); err != nil {
+		return Values{}, err
+	} else if status != "" {
+		v.BuildDirty = "true"
+	}
+	if branch, err := git(dir, "rev-parse", "--abbrev-ref", "HEAD"); err == nil && branch != "HEAD" {
+		v.BuildBranch = branch
+	}
+	v.BuildTag, _ = git(dir, "describe", "--tags", "--exact-match")
+	v.CommitSubject, _ = git(dir, "log", "-1", "--format=%s")
+	v.DirectModules, _ = directModules(dir)
+
+	detectGitHub(&v)
+	detectGitLab(&v)
+
+	v.BuildTimeUnix = strconv.FormatInt(time.Now().Unix(), 10)
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		v.BuildTimeUnix = epoch
+	}
+	v.ReleaseVersion = v.BuildTag
+	if rv := os.Getenv("RELEASE_VERSION"); rv != "" {
+		v.ReleaseVersion = rv
+	}
+	return v, nil
+}
+
+// https://docs.github.com/en/actions/reference/variables-reference
+func detectGitHub(v *Values) {
+	if os.Getenv("GITHUB_ACTIONS") != "true" {
+		return
+	}
+	v.CIProvider = "github"
+	v.CIPipelineID = os.Getenv("GITHUB_RUN_ID")
+	v.CIJobID = os.Getenv("GITHUB_JOB")
+	v.CIRunner = os.Getenv("RUNNER_NAME")
+	if server, repo := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"); server != "" && repo != "" && v.CIPipelineID != "" {
+		v.BuildURL = server + "/" + repo + "/actions/runs/" + v.CIPipelineID
+	}
+	switch os.Getenv("GITHUB_REF_TYPE") {
+	case "branch":
+		v.BuildBranch = os.Getenv("GITHUB_REF_NAME")
+	case "tag":
+		v.BuildTag = os.Getenv("GITHUB_REF_NAME")
+	}
+}
+
+// https://docs.gitlab.com/ci/variables/predefined_variables/
+func detectGitLab(v *Values) {
+	if os.Getenv("GITLAB_CI") != "true" {
+		return
+	}
+	v.CIProvider = "gitlab"
+	v.CIPipelineID = os.Getenv("CI_PIPELINE_ID")
+	v.CIJobID = os.Getenv("CI_JOB_ID")
+	v.CIRunner = os.Getenv("CI_RUNNER_DESCRIPTION")
+	v.BuildURL = os.Getenv("CI_PIPELINE_URL")
+	if branch := os.Getenv("CI_COMMIT_BRANCH"); branch != "" {
+		v.BuildBranch = branch
+	}
+	if tag := os.Getenv("CI_COMMIT_TAG"); tag != "" {
+		v.BuildTag = tag
+	}
+}
+
+func git(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("ldflags: git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("ldflags: git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// directModules returns the comma separated paths of the modules required
+// directly by the go.mod file in dir.
+func directModules(dir string) (string, error) {
+	cmd := exec.Command("go", "mod", "edit", "-json")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("ldflags: go mod edit: %w", err)
+	}
+	var mod struct {
+		Require []struct {
+			Path     string
+			Indirect bool
+		}
+	}
+	if err := json.Unmarshal(out, &mod); err != nil {
+		return "", fmt.Errorf("ldflags: go mod edit: %w", err)
+	}
+	var paths []string
+	for _, r := range mod.Require {
+		if !r.Indirect {
+			paths = append(paths, r.Path)
+		}
+	}
+	return strings.Join(paths, ","), nil
+}
+
+// String returns the -ldflags value, quoting values as needed for the go
+// command.
+func (v Values) String() string {
+	var flags []string
+	for _, kv := range []struct{ name, value string }{
+		{"buildTimeUnix", v.BuildTimeUnix},
+		{"buildHash", v.BuildHash},
+		{"releaseVersion", v.ReleaseVersion},
+		{"buildURL", v.BuildURL},
+		{"buildBranch", v.BuildBranch},
+		{"buildTag", v.BuildTag},
+		{"commitSubject", v.CommitSubject},
+		{"buildDirty", v.BuildDirty},
+		{"ciProvider", v.CIProvider},
+		{"ciPipelineID", v.CIPipelineID},
+		{"ciJobID", v.CIJobID},
+		{"ciRunner", v.CIRunner},
+		{"directModules", v.DirectModules},
+	} {
+		if kv.value != "" {
+			flags = append(flags, "-X", quote(prefix+kv.name+"="+kv.value))
+		}
+	}
+	return strings.Join(flags, " ")
+}
+
+// quote quotes s the way the go command expects within -ldflags. Values
+// containing both kinds of quotes cannot be represented, so the double quotes
+// are replaced.
+func quote(s string) string {
+	if !strings.ContainsAny(s, " \t\n\r'\"") {
+		return s
+	}
+	if !strings.Contains(s, "'") {
+		return "'" + s + "'"
+	}
+	return `"` + strings.ReplaceAll(s, `"`, "'") + `"`
+}
+
+// Info returns the build information the values result in, without the
+// fallbacks applied at runtime.
+func (v Values) Info() buildinfo.Info {
+	i := buildinfo.Info{
+		ReleaseVersion: v.ReleaseVersion,
+		BuildHash:      v.BuildHash,
+		BuildURL:       v.BuildURL,
+	}
+	if dirty, _ := strconv.ParseBool(v.BuildDirty); dirty && i.BuildHash != "" {
+		i.BuildHash += "-dirty"
+	}
+	if unix, err := strconv.ParseInt(v.BuildTimeUnix, 10, 64); err == nil {
+		i.BuildTime = time.Unix(unix, 0)
+	}
+	return i
+}
+
+// DockerArgs returns the docker build arguments setting the OCI image labels
+// for the values, so the image metadata matches the binary. The values are not
+// quoted, as none of the labels contain spaces.
+func (v Values) DockerArgs() string {
+	labels := v.Info().OCILabels()
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	args := make([]string, 0, len(keys))
+	for _, k := range keys {
+		args = append(args, "--label "+k+"="+labels[k])
+	}
+	return strings.Join(args, " ")
+}
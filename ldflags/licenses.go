@@ -0,0 +1,139 @@
+package ldflags
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/daaku/buildinfo"
+)
+
+// Licenses determines the licenses of the modules providing the packages pkgs
+// and their dependencies, as built from the module at dir, for the manifest
+// read by buildinfo.LicensesFromEmbed. The packages default to "./...". The
+// license is detected from the license file found in the module cache, and is
+// reported as "unknown" if the file is missing or not recognized.
+func Licenses(dir string, pkgs ...string) ([]buildinfo.ModuleLicense, error) {
+	if len(pkgs) == 0 {
+		pkgs = []string{"./..."}
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", append([]string{"list", "-deps", "-json=Module"}, pkgs...)...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("ldflags: go list: %s", msg)
+		}
+		return nil, fmt.Errorf("ldflags: go list: %w", err)
+	}
+
+	type module struct {
+		Path    string
+		Version string
+		Dir     string
+		Main    bool
+		Replace *module
+	}
+	seen := map[string]bool{}
+	var manifest []buildinfo.ModuleLicense
+	dec := json.NewDecoder(&stdout)
+	for {
+		var pkg struct{ Module *module }
+		if err := dec.Decode(&pkg); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("ldflags: go list: %w", err)
+		}
+		m := pkg.Module
+		// Packages in the standard library have no module.
+		if m == nil || m.Main || seen[m.Path] {
+			continue
+		}
+		seen[m.Path] = true
+		l := buildinfo.ModuleLicense{Path: m.Path, Version: m.Version}
+		if m.Replace != nil {
+			m = m.Replace
+			if m.Version != "" {
+				l.Version = m.Version
+			}
+		}
+		l.License = detectLicense(m.Dir)
+		manifest = append(manifest, l)
+	}
+	sort.Slice(manifest, func(i, j int) bool { return manifest[i].Path < manifest[j].Path })
+	return manifest, nil
+}
+
+// detectLicense returns the SPDX identifier of the license found in dir.
+func detectLicense(dir string) string {
+	if dir == "" {
+		return "unknown"
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "unknown"
+	}
+	for _, e := range entries {
+		name := strings.ToLower(e.Name())
+		if e.IsDir() || !(strings.HasPrefix(name, "license") ||
+			strings.HasPrefix(name, "licence") || strings.HasPrefix(name, "copying")) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		if id := classifyLicense(string(data)); id != "" {
+			return id
+		}
+	}
+	return "unknown"
+}
+
+// licensePhrases identify the common licenses by their text, in the order
+// they are checked, as some of the texts refer to the others.
+var licensePhrases = []struct {
+	id      string
+	phrases []string
+}{
+	{"Apache-2.0", []string{"apache license", "version 2.0"}},
+	{"MPL-2.0", []string{"mozilla public license", "2.0"}},
+	{"AGPL-3.0", []string{"gnu affero general public license", "version 3"}},
+	{"LGPL-3.0", []string{"gnu lesser general public license", "version 3"}},
+	{"LGPL-2.1", []string{"gnu lesser general public license", "version 2.1"}},
+	{"GPL-3.0", []string{"gnu general public license", "version 3"}},
+	{"GPL-2.0", []string{"gnu general public license", "version 2"}},
+	{"BSD-3-Clause", []string{"redistribution and use in source and binary forms", "endorse or promote"}},
+	{"BSD-2-Clause", []string{"redistribution and use in source and binary forms"}},
+	{"MIT", []string{"permission is hereby granted, free of charge"}},
+	{"ISC", []string{"permission to use, copy, modify, and", "for any purpose with or without fee"}},
+	{"Unlicense", []string{"free and unencumbered software released into the public domain"}},
+	{"CC0-1.0", []string{"cc0 1.0"}},
+}
+
+// classifyLicense returns the SPDX identifier matching text, or "" if it is
+// not recognized.
+func classifyLicense(text string) string {
+	text = strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	for _, l := range licensePhrases {
+		matched := true
+		for _, p := range l.phrases {
+			if !strings.Contains(text, p) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return l.id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package buildinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"text/tabwriter"
+)
+
+// ModuleLicense is the license of a module linked into the binary.
+type ModuleLicense struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+
+	// License is the SPDX identifier of the license, or "unknown" if it could
+	// not be determined.
+	License string `json:"license"`
+}
+
+var licenses []ModuleLicense
+
+// LicensesFromEmbed reads the license manifest from the JSON file name in fsys.
+// The manifest is generated from the module cache at build time using:
+//
+//	go run github.com/daaku/buildinfo/cmd/buildinfo-ldflags -format licenses > licenses.json
+//
+// and is meant to be embedded into the binary using go:embed, in the same way
+// as with FromEmbed. It must be called during program initialization, before
+// the build information is used concurrently.
+func LicensesFromEmbed(fsys fs.FS, name string) error {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("buildinfo: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var manifest []ModuleLicense
+	if err := dec.Decode(&manifest); err != nil {
+		return fmt.Errorf("buildinfo: invalid %s: %w", name, err)
+	}
+	sort.Slice(manifest, func(i, j int) bool { return manifest[i].Path < manifest[j].Path })
+	licenses = manifest
+	return nil
+}
+
+// Licenses returns the licenses of the modules linked into the binary, sorted
+// by module path, or nil if no manifest was loaded using LicensesFromEmbed.
+func Licenses() []ModuleLicense {
+	return append([]ModuleLicense(nil), licenses...)
+}
+
+// LicenseInfo provides a pretty table of the number of modules per license,
+// followed by the license of each module. It is included in FullInfo when a
+// manifest was loaded using LicensesFromEmbed.
+func LicenseInfo() string {
+	var b bytes.Buffer
+	_ = WriteLicenseInfo(&b)
+	return b.String()
+}
+
+// WriteLicenseInfo writes LicenseInfo() to w.
+func WriteLicenseInfo(w io.Writer) error {
+	sw := &stickyWriter{w: w}
+	fmt.Fprint(sw, "Licenses:\n")
+	if licenses == nil {
+		fmt.Fprint(sw, "No license manifest\n")
+		return sw.err
+	}
+	counts := map[string]int{}
+	var ids []string
+	for _, l := range licenses {
+		if counts[l.License] == 0 {
+			ids = append(ids, l.License)
+		}
+		counts[l.License]++
+	}
+	sort.Strings(ids)
+	tw := tabwriter.NewWriter(sw, 0, 0, 1, ' ', 0)
+	for _, id := range ids {
+		fmt.Fprintf(tw, "%s\t%d\n", id, counts[id])
+	}
+	fmt.Fprint(tw, "\n")
+	for _, l := range licenses {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", l.Path, l.Version, l.License)
+	}
+	_ = tw.Flush()
+	return sw.err
+}
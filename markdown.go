@@ -0,0 +1,50 @@
+package buildinfo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Markdown returns the BasicInfo() and ModuleInfo() as Markdown tables,
+// suitable for release notes and incident documents.
+func Markdown(opts ...Option) []byte {
+	var b bytes.Buffer
+	_ = WriteMarkdown(&b, opts...)
+	return b.Bytes()
+}
+
+// WriteMarkdown writes Markdown(opts...) to w.
+func WriteMarkdown(w io.Writer, opts ...Option) error {
+	sw := &stickyWriter{w: w}
+	if retracted {
+		fmt.Fprint(sw, "**WARNING: this version has been retracted**\n\n")
+	}
+	fmt.Fprint(sw, "| Field | Value |\n| --- | --- |\n")
+	for _, f := range basicFields(newOptions(opts)) {
+		fmt.Fprintf(sw, "| %s | %s |\n", markdownEscape(f.Name), markdownEscape(f.Value))
+	}
+	if mods := redactModules(modules); len(mods) > 0 {
+		fmt.Fprint(sw, "\n| Module | Version |\n| --- | --- |\n")
+		for _, m := range mods {
+			fmt.Fprintf(sw, "| %s | %s |\n", markdownEscape(m.Path), markdownEscape(m.Version))
+		}
+	}
+	return sw.err
+}
+
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"<", "&lt;",
+	"\n", " ",
+)
+
+// markdownEscape escapes s for use in a Markdown table cell.
+func markdownEscape(s string) string {
+	return markdownReplacer.Replace(s)
+}
@@ -0,0 +1,46 @@
+// Package metrics exposes build information as Prometheus metrics.
+package metrics
+
+import (
+	"runtime"
+
+	"github.com/daaku/buildinfo"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	buildInfoDesc = prometheus.NewDesc(
+		"build_info",
+		"A metric with a constant '1' value labeled by the build information.",
+		[]string{"version", "hash", "goversion"},
+		nil,
+	)
+	startTimeDesc = prometheus.NewDesc(
+		"process_start_time_seconds",
+		"Start time of the process since unix epoch in seconds.",
+		nil,
+		nil,
+	)
+)
+
+type collector struct{}
+
+// Collector returns a prometheus.Collector exposing a constant build_info
+// gauge along with process_start_time_seconds derived from
+// buildinfo.StartupTime(). The latter is also exposed by the standard process
+// collector, so the two should not be registered together.
+func Collector() prometheus.Collector {
+	return collector{}
+}
+
+func (collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- buildInfoDesc
+	ch <- startTimeDesc
+}
+
+func (collector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(buildInfoDesc, prometheus.GaugeValue, 1,
+		buildinfo.ReleaseVersion(), buildinfo.BuildHash(), runtime.Version())
+	ch <- prometheus.MustNewConstMetric(startTimeDesc, prometheus.GaugeValue,
+		float64(buildinfo.StartupTime().UnixNano())/1e9)
+}
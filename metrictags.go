@@ -0,0 +1,41 @@
+package buildinfo
+
+import (
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// metricTagReplacer replaces the characters with a special meaning in the
+// DogStatsD protocol.
+var metricTagReplacer = strings.NewReplacer(",", "_", "|", "_", "#", "_", " ", "_")
+
+// MetricTags returns the build identity as metric tags in the "key:value"
+// form DogStatsD expects, sorted by key, such as:
+//
+//	[]string{"git_sha:abc1234", "go_version:go1.22.1", "version:1.4.2"}
+func MetricTags() []string {
+	m := MetricTagMap()
+	tags := make([]string, 0, len(m))
+	for k, v := range m {
+		tags = append(tags, k+":"+v)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// MetricTagMap returns the same tags as MetricTags, as a map for other metric
+// sinks.
+func MetricTagMap() map[string]string {
+	return metricTagMap(runtime.Version())
+}
+
+// metricTagMap returns the tags for the given Go version, which may contain
+// spaces, as in "go1.22.0 X:boringcrypto".
+func metricTagMap(goVersion string) map[string]string {
+	return map[string]string{
+		"version":    metricTagReplacer.Replace(releaseVersion),
+		"git_sha":    metricTagReplacer.Replace(buildHash),
+		"go_version": metricTagReplacer.Replace(goVersion),
+	}
+}
@@ -0,0 +1,24 @@
+package buildinfo
+
+import "testing"
+
+func TestMetricTagMapSanitizes(t *testing.T) {
+	saved, savedHash := releaseVersion, buildHash
+	t.Cleanup(func() { releaseVersion, buildHash = saved, savedHash })
+	releaseVersion, buildHash = "v1.2.3 rc,1", "abc|def"
+
+	got := metricTagMap("go1.22.0 X:boringcrypto")
+	want := map[string]string{
+		"version":    "v1.2.3_rc_1",
+		"git_sha":    "abc_def",
+		"go_version": "go1.22.0_X:boringcrypto",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("got %s %q, want %q", k, got[k], v)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("got tags %v, want %v", got, want)
+	}
+}
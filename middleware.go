@@ -0,0 +1,19 @@
+package buildinfo
+
+import "net/http"
+
+const (
+	buildHashHeader      = "X-Build-Hash"
+	releaseVersionHeader = "X-Release-Version"
+)
+
+// HTTPMiddleware returns a handler that adds the X-Build-Hash and
+// X-Release-Version headers to every response from next.
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Set(buildHashHeader, buildHash)
+		h.Set(releaseVersionHeader, releaseVersion)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,129 @@
+package buildinfo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// ModuleInfoOpts controls which modules are included by ModuleInfoWith.
+type ModuleInfoOpts struct {
+	// Sort sorts the modules by path, instead of the order reported by the go
+	// command.
+	Sort bool
+
+	// Include limits the modules to those matching one of the patterns. A
+	// pattern matches a module path and the paths below it, and may end in
+	// "/..." to only match the paths below it, such as
+	// "github.com/mycompany/...".
+	Include []string
+
+	// Exclude removes the modules matching one of the patterns, which have
+	// the same form as in Include.
+	Exclude []string
+
+	// Limit is the maximum number of modules listed, with 0 meaning no limit.
+	Limit int
+
+	// Replace includes the targets of replace directives.
+	Replace bool
+
+	// Sum includes the module checksums.
+	Sum bool
+
+	// Direct limits the modules to the direct dependencies of the main
+	// module. It is ignored if the direct modules were not provided via
+	// ldflags, as reported by ModuleStats.
+	Direct bool
+}
+
+// ModuleInfoWith is like ModuleInfo, but only includes the modules selected by
+// opts.
+func ModuleInfoWith(opts ModuleInfoOpts) string {
+	var b bytes.Buffer
+	_ = WriteModuleInfoWith(&b, opts)
+	return b.String()
+}
+
+// WriteModuleInfoWith writes ModuleInfoWith(opts) to w.
+func WriteModuleInfoWith(w io.Writer, opts ModuleInfoOpts) error {
+	if modules == nil {
+		return nil
+	}
+	return writeModules(w, redactModules(opts.filter(modules)), opts)
+}
+
+func (o ModuleInfoOpts) filter(mods []Module) []Module {
+	var direct map[string]bool
+	if o.Direct {
+		direct = directSet()
+	}
+	var selected []Module
+	for _, m := range mods {
+		if direct != nil && !direct[m.Path] {
+			continue
+		}
+		if len(o.Include) > 0 && !matchAny(o.Include, m.Path) {
+			continue
+		}
+		if matchAny(o.Exclude, m.Path) {
+			continue
+		}
+		selected = append(selected, m)
+	}
+	if o.Sort {
+		sort.Slice(selected, func(i, j int) bool {
+			return selected[i].Path < selected[j].Path
+		})
+	}
+	return selected
+}
+
+func matchAny(patterns []string, path string) bool {
+	for _, p := range patterns {
+		if strings.HasSuffix(p, "/...") {
+			if strings.HasPrefix(path, strings.TrimSuffix(p, "...")) {
+				return true
+			}
+			continue
+		}
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// writeModules writes the module table as configured by opts. Filtering is
+// left to the caller.
+func writeModules(w io.Writer, mods []Module, opts ModuleInfoOpts) error {
+	sw := &stickyWriter{w: w}
+	fmt.Fprint(sw, "Modules:\n")
+	tw := tabwriter.NewWriter(sw, 0, 0, 1, ' ', 0)
+	for i, m := range mods {
+		if opts.Limit > 0 && i == opts.Limit {
+			fmt.Fprintf(tw, "... and %d more\n", len(mods)-opts.Limit)
+			break
+		}
+		fmt.Fprintf(tw, "%s\t%s", m.Path, m.Version)
+		sum := m.Sum
+		if opts.Replace && m.Replace != nil {
+			fmt.Fprintf(tw, "\t=> %s", m.Replace.Path)
+			if m.Replace.Version != "" {
+				fmt.Fprintf(tw, " %s", m.Replace.Version)
+			}
+			sum = m.Replace.Sum
+		} else if opts.Replace {
+			fmt.Fprint(tw, "\t")
+		}
+		if opts.Sum {
+			fmt.Fprintf(tw, "\t%s", sum)
+		}
+		fmt.Fprint(tw, "\n")
+	}
+	_ = tw.Flush()
+	return sw.err
+}
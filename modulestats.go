@@ -0,0 +1,93 @@
+package buildinfo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// directModules may be provided via ldflags as the comma separated paths of
+// the modules the main module requires directly, which the go command does not
+// record in the binary. The buildinfo-ldflags command provides it.
+var directModules = ""
+
+// ModuleSummary are the summary statistics of the modules linked into the
+// binary.
+type ModuleSummary struct {
+	Total int `json:"total"`
+
+	// Direct and Indirect are the number of direct and indirect dependencies
+	// of the main module, or -1 if the direct modules were not provided via
+	// ldflags.
+	Direct   int `json:"direct"`
+	Indirect int `json:"indirect"`
+
+	// Replaced is the number of modules replaced by a replace directive.
+	Replaced int `json:"replaced"`
+
+	// PseudoVersions is the number of modules at a pseudo-version, such as
+	// v0.0.0-20191010083416-a7dc8b61c822, rather than a tagged release.
+	PseudoVersions int `json:"pseudo_versions"`
+}
+
+// pseudoVersionRE matches the pseudo-versions generated by the go command, as
+// described at https://go.dev/ref/mod#pseudo-versions.
+var pseudoVersionRE = regexp.MustCompile(`^v[0-9]+\.(0\.0-|\d+\.\d+-([^+]*\.)?0\.)\d{14}-[A-Za-z0-9]+(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// ModuleStats returns the summary statistics of the modules linked into the
+// binary. Replaced modules are counted at the version of their replacement.
+// The direct dependencies are only known when their paths are provided, by the
+// buildinfo-ldflags command or as:
+//
+//	LDFLAGS="$LDFLAGS -X github.com/daaku/buildinfo.directModules=github.com/spf13/cobra,google.golang.org/grpc"
+func ModuleStats() ModuleSummary {
+	s := ModuleSummary{Total: len(modules), Direct: -1, Indirect: -1}
+	direct := directSet()
+	if direct != nil {
+		s.Direct, s.Indirect = 0, 0
+	}
+	for _, m := range modules {
+		if m.Replace != nil {
+			s.Replaced++
+		}
+		if pseudoVersionRE.MatchString(effectiveVersion(m)) {
+			s.PseudoVersions++
+		}
+		if direct != nil {
+			if direct[m.Path] {
+				s.Direct++
+			} else {
+				s.Indirect++
+			}
+		}
+	}
+	return s
+}
+
+// String returns the statistics in a single line, such as:
+//
+//	42 modules (5 direct, 37 indirect), 1 replaced, 3 pseudo-versions
+func (s ModuleSummary) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d modules", s.Total)
+	if s.Direct >= 0 {
+		fmt.Fprintf(&b, " (%d direct, %d indirect)", s.Direct, s.Indirect)
+	}
+	fmt.Fprintf(&b, ", %d replaced, %d pseudo-versions", s.Replaced, s.PseudoVersions)
+	return b.String()
+}
+
+// directSet returns the set of direct modules, or nil if they were not
+// provided.
+func directSet() map[string]bool {
+	if directModules == "" {
+		return nil
+	}
+	set := map[string]bool{}
+	for _, p := range strings.Split(directModules, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			set[p] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,29 @@
+package buildinfo
+
+import "time"
+
+// OCILabels returns the standard org.opencontainers.image.* labels describing
+// this build. See Info.OCILabels.
+func OCILabels() map[string]string {
+	return Get().OCILabels()
+}
+
+// OCILabels returns the standard org.opencontainers.image.* labels describing
+// the build: the revision, version, created time and, when it can be derived
+// from the BuildURL, the source repository. Blank values are left out.
+func (i Info) OCILabels() map[string]string {
+	labels := map[string]string{}
+	add := func(key, value string) {
+		if value != "" {
+			labels["org.opencontainers.image."+key] = value
+		}
+	}
+	add("revision", i.BuildHash)
+	add("version", i.ReleaseVersion)
+	if !i.BuildTime.IsZero() && i.BuildTime.Unix() != 0 {
+		add("created", i.BuildTime.UTC().Format(time.RFC3339))
+	}
+	repo, _ := repoFromBuildURL(i.BuildURL)
+	add("source", repo)
+	return labels
+}
@@ -0,0 +1,27 @@
+package buildinfo
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// BuildOnce is a sync.Once that logs the release version of this binary the
+// first time it runs a function. The zero value is ready to use.
+type BuildOnce struct {
+	sync.Once
+}
+
+// Do calls fn if and only if Do is being called for the first time for this
+// instance of BuildOnce, and then logs the release version.
+func (o *BuildOnce) Do(fn func()) {
+	o.Once.Do(func() {
+		fn()
+		log.Printf("initialized service at version %s", releaseVersion)
+	})
+}
+
+// DoWithContext is like Do, but passes ctx to fn.
+func (o *BuildOnce) DoWithContext(ctx context.Context, fn func(context.Context)) {
+	o.Do(func() { fn(ctx) })
+}
@@ -0,0 +1,11 @@
+//go:build testing
+
+package buildinfo
+
+import "sync"
+
+// Reset allows the next call to Do or DoWithContext to run again. It is only
+// available when building with the testing tag.
+func (o *BuildOnce) Reset() {
+	o.Once = sync.Once{}
+}
@@ -0,0 +1,35 @@
+package buildinfo
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OneLine returns the build information as a single logfmt line, such as:
+//
+//	version=1.4.2 hash=abc1234 built=2024-05-01T12:00:00Z go=go1.22.1
+func OneLine() string {
+	var b strings.Builder
+	writeLogfmt(&b, "version", releaseVersion)
+	writeLogfmt(&b, "hash", buildHash)
+	if buildTimeUnix != "0" {
+		writeLogfmt(&b, "built", buildTime.UTC().Format(time.RFC3339))
+	}
+	writeLogfmt(&b, "go", runtime.Version())
+	return b.String()
+}
+
+func writeLogfmt(b *strings.Builder, key, value string) {
+	if b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+	b.WriteString(key)
+	b.WriteByte('=')
+	if value == "" || strings.ContainsAny(value, " =\"\\") || !strconv.CanBackquote(value) {
+		b.WriteString(strconv.Quote(value))
+	} else {
+		b.WriteString(value)
+	}
+}
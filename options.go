@@ -0,0 +1,114 @@
+package buildinfo
+
+import (
+	"io"
+	"time"
+)
+
+// Option configures the rendered output, such as that of BasicInfo and
+// FullInfo.
+type Option func(*options)
+
+type options struct {
+	runtime        bool
+	runtimeMetrics bool
+	checksum       bool
+	noModules      bool
+	color          ColorMode
+
+	// customKeys limits the custom fields to those listed, if not nil.
+	customKeys []string
+
+	// w is the writer set with Writer.
+	w io.Writer
+
+	moduleCount bool
+	precision   time.Duration
+
+	location   *time.Location
+	timeLayout string
+}
+
+func newOptions(opts []Option) options {
+	o := options{location: time.UTC, timeLayout: time.RFC3339}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithRuntime includes the RuntimeInfo() section in FullInfo.
+func WithRuntime() Option {
+	return func(o *options) { o.runtime = true }
+}
+
+// WithExecutableChecksum includes the ExecutableChecksum() of the running
+// binary in FullInfo.
+func WithExecutableChecksum() Option {
+	return func(o *options) { o.checksum = true }
+}
+
+// WithoutModules leaves out the ModuleInfo() section from FullInfo.
+func WithoutModules() Option {
+	return func(o *options) { o.noModules = true }
+}
+
+// WithCustomFields limits the custom fields added with Register to those with
+// the given keys. Without any keys, all custom fields are included, as they
+// are by default; use WithoutCustomFields to leave them all out.
+func WithCustomFields(keys ...string) Option {
+	return func(o *options) {
+		o.customKeys = nil
+		if len(keys) > 0 {
+			o.customKeys = append([]string{}, keys...)
+		}
+	}
+}
+
+// WithoutCustomFields leaves out all custom fields added with Register.
+func WithoutCustomFields() Option {
+	return func(o *options) { o.customKeys = []string{} }
+}
+
+// Writer makes BasicInfo and FullInfo write their output to w instead of
+// returning it, which allows ColorAuto to detect a terminal. Write errors are
+// ignored, and the functions that take a writer, such as WriteFullInfo, are not
+// affected.
+func Writer(w io.Writer) Option {
+	return func(o *options) { o.w = w }
+}
+
+// includeCustom returns true if the custom field with key is included.
+func (o options) includeCustom(key string) bool {
+	if o.customKeys == nil {
+		return true
+	}
+	for _, k := range o.customKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// WithLocation sets the location used to print times. It defaults to UTC,
+// which is also used if loc is nil.
+func WithLocation(loc *time.Location) Option {
+	return func(o *options) {
+		if loc == nil {
+			loc = time.UTC
+		}
+		o.location = loc
+	}
+}
+
+// WithTimeLayout sets the layout used to print times, as accepted by
+// time.Time.Format. It defaults to time.RFC3339.
+func WithTimeLayout(layout string) Option {
+	return func(o *options) { o.timeLayout = layout }
+}
+
+// formatTime formats t as configured by o.
+func (o options) formatTime(t time.Time) string {
+	return t.In(o.location).Format(o.timeLayout)
+}
@@ -0,0 +1,45 @@
+package buildinfo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCustomFieldOptions(t *testing.T) {
+	customFieldsMu.Lock()
+	saved := customFields
+	customFields = []customField{
+		{key: "a", value: func() string { return "1" }},
+		{key: "b", value: func() string { return "2" }},
+	}
+	customFieldsMu.Unlock()
+	t.Cleanup(func() {
+		customFieldsMu.Lock()
+		customFields = saved
+		customFieldsMu.Unlock()
+	})
+
+	cases := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{name: "default", want: []string{"a", "b"}},
+		{name: "no keys", opts: []Option{WithCustomFields()}, want: []string{"a", "b"}},
+		{name: "some keys", opts: []Option{WithCustomFields("b", "missing")}, want: []string{"b"}},
+		{name: "without", opts: []Option{WithoutCustomFields()}},
+		{name: "without then keys", opts: []Option{WithoutCustomFields(), WithCustomFields("a")}, want: []string{"a"}},
+		{name: "keys then without", opts: []Option{WithCustomFields("a"), WithoutCustomFields()}},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, f := range basicFields(newOptions(c.opts)) {
+			if f.Name == "a" || f.Name == "b" {
+				got = append(got, f.Name)
+			}
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got custom fields %v, want %v", c.name, got, c.want)
+		}
+	}
+}
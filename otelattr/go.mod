@@ -0,0 +1,10 @@
+module github.com/daaku/buildinfo/otelattr
+
+go 1.25.0
+
+require (
+	github.com/daaku/buildinfo v0.1.0
+	go.opentelemetry.io/otel v1.46.0
+)
+
+require github.com/cespare/xxhash/v2 v2.3.0 // indirect
@@ -0,0 +1,30 @@
+// Package otelattr maps build information to OpenTelemetry resource
+// attributes.
+package otelattr
+
+import (
+	"time"
+
+	"github.com/daaku/buildinfo"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+)
+
+const (
+	// BuildHashKey is the attribute key for buildinfo.BuildHash().
+	BuildHashKey = attribute.Key("build.hash")
+
+	// BuildTimeKey is the attribute key for buildinfo.BuildTime(), formatted
+	// as RFC 3339.
+	BuildTimeKey = attribute.Key("build.time")
+)
+
+// Attributes returns the resource attributes describing this build, for use
+// with resource.NewWithAttributes or resource.WithAttributes.
+func Attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.ServiceVersion(buildinfo.ReleaseVersion()),
+		BuildHashKey.String(buildinfo.BuildHash()),
+		BuildTimeKey.String(buildinfo.BuildTime().UTC().Format(time.RFC3339)),
+	}
+}
@@ -0,0 +1,135 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const owaspMaxBuildAge = 90 * 24 * time.Hour
+
+// OWASPReport is the result of verifying this build against the artifact
+// verification requirements of the OWASP Application Security Verification
+// Standard.
+type OWASPReport struct {
+	// Level is the highest level for which all checks passed, or 0 if even the
+	// level 1 checks failed.
+	Level int `json:"level"`
+
+	// Passed and Failed contain the descriptions of the checks.
+	Passed []string `json:"passed"`
+	Failed []string `json:"failed"`
+
+	// Score is the percentage of all checks that passed.
+	Score float64 `json:"score"`
+
+	results []owaspResult
+}
+
+type owaspResult struct {
+	level int
+	name  string
+	ok    bool
+}
+
+type owaspCheck struct {
+	level int
+	name  string
+	ok    func(settings map[string]string) bool
+}
+
+var owaspCGOAllowed int32
+
+// SetOWASPCGOAllowed configures whether builds with CGO enabled pass
+// verification. By default they do not.
+func SetOWASPCGOAllowed(allowed bool) {
+	var v int32
+	if allowed {
+		v = 1
+	}
+	atomic.StoreInt32(&owaspCGOAllowed, v)
+}
+
+var owaspChecks = []owaspCheck{
+	{1, "release version is set", func(map[string]string) bool {
+		return releaseVersion != "" && releaseVersion != "dev"
+	}},
+	{1, "build hash is present", func(map[string]string) bool {
+		return buildHash != "" && buildHash != "dev"
+	}},
+	{2, "build URL is set", func(map[string]string) bool {
+		return buildURL != ""
+	}},
+	{2, "build time is within 90 days", func(map[string]string) bool {
+		return buildTimeUnix != "0" && time.Since(buildTime) <= owaspMaxBuildAge
+	}},
+	{2, "build is not dirty", func(map[string]string) bool {
+		return !Dirty()
+	}},
+	{3, "CGO is disabled", func(s map[string]string) bool {
+		return atomic.LoadInt32(&owaspCGOAllowed) == 1 || s["CGO_ENABLED"] != "1"
+	}},
+	{3, "race detector is off", func(s map[string]string) bool {
+		return s["-race"] != "true"
+	}},
+}
+
+// OWASPBuildVerification runs the build verification checks.
+func OWASPBuildVerification() OWASPReport {
+	r := OWASPReport{Level: 3, Passed: []string{}, Failed: []string{}}
+	for _, c := range owaspChecks {
+		ok := c.ok(buildSettings)
+		r.results = append(r.results, owaspResult{c.level, c.name, ok})
+		if ok {
+			r.Passed = append(r.Passed, c.name)
+			continue
+		}
+		r.Failed = append(r.Failed, c.name)
+		if c.level-1 < r.Level {
+			r.Level = c.level - 1
+		}
+	}
+	r.Score = 100 * float64(len(r.Passed)) / float64(len(owaspChecks))
+	return r
+}
+
+// levelScore returns the percentage of the checks required for level that
+// passed, along with the descriptions of those that failed.
+func (r OWASPReport) levelScore(level int) (float64, []string) {
+	var total, passed int
+	var failed []string
+	for _, c := range r.results {
+		if c.level > level {
+			continue
+		}
+		total++
+		if c.ok {
+			passed++
+		} else {
+			failed = append(failed, c.name)
+		}
+	}
+	if total == 0 {
+		return 100, nil
+	}
+	return 100 * float64(passed) / float64(total), failed
+}
+
+// WriteOWASPReport writes the JSON encoded OWASPBuildVerification report to w.
+func WriteOWASPReport(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(OWASPBuildVerification())
+}
+
+// FailIfOWASPLevel panics if any of the checks required for level fail.
+func FailIfOWASPLevel(level int) {
+	r := OWASPBuildVerification()
+	if score, failed := r.levelScore(level); score < 100 {
+		panic(fmt.Sprintf("buildinfo: OWASP level %d verification failed (%.0f%%): %s",
+			level, score, strings.Join(failed, ", ")))
+	}
+}
@@ -0,0 +1,9 @@
+package buildinfo
+
+// BuildInfoExport is the JSON encoded Info of this binary. It allows a
+// host process to audit a plugin built with -buildmode=plugin using
+// pluginload.Load. Since plugin.Lookup only finds symbols in the main
+// package of a plugin, the plugin must re-export it:
+//
+//	var BuildInfoExport = buildinfo.BuildInfoExport
+var BuildInfoExport string
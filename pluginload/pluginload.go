@@ -0,0 +1,43 @@
+//go:build (linux || darwin || freebsd) && cgo
+
+// Package pluginload reads the build information of Go plugins. It is
+// separate from the buildinfo package because importing the plugin package
+// disables the removal of unused code by the linker, which roughly doubles the
+// size of every binary that includes it.
+package pluginload
+
+import (
+	"encoding/json"
+	"fmt"
+	"plugin"
+
+	"github.com/daaku/buildinfo"
+)
+
+// Load opens the plugin at path and returns the Info it exports as
+// buildinfo.BuildInfoExport. Opening a plugin runs its init functions.
+// Plugins are only supported on Linux, FreeBSD and macOS, and require CGO.
+func Load(path string) (buildinfo.Info, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return buildinfo.Info{}, err
+	}
+	sym, err := p.Lookup("BuildInfoExport")
+	if err != nil {
+		return buildinfo.Info{}, err
+	}
+	var data string
+	switch v := sym.(type) {
+	case *string:
+		data = *v
+	case func() string:
+		data = v()
+	default:
+		return buildinfo.Info{}, fmt.Errorf("pluginload: plugin %s exports BuildInfoExport as unexpected type %T", path, sym)
+	}
+	var info buildinfo.Info
+	if err := json.Unmarshal([]byte(data), &info); err != nil {
+		return buildinfo.Info{}, fmt.Errorf("pluginload: invalid BuildInfoExport in plugin %s: %w", path, err)
+	}
+	return info, nil
+}
@@ -0,0 +1,17 @@
+//go:build !((linux || darwin || freebsd) && cgo)
+
+package pluginload
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/daaku/buildinfo"
+)
+
+// Load opens the plugin at path and returns the Info it exports as
+// buildinfo.BuildInfoExport. Plugins are only supported on Linux, FreeBSD and
+// macOS, and require CGO, so it always fails on this platform.
+func Load(path string) (buildinfo.Info, error) {
+	return buildinfo.Info{}, errors.New("pluginload: plugins are not supported on " + runtime.GOOS + " or without cgo")
+}
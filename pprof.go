@@ -0,0 +1,18 @@
+package buildinfo
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// WithPprofLabels returns a copy of ctx with the release version and build
+// hash added as the "version" and "build_hash" pprof labels, so profiles
+// collected across a fleet can be grouped by build. The labels apply to the
+// samples of goroutines that use the context with pprof.Do or
+// pprof.SetGoroutineLabels. Calling the latter early in main labels all
+// goroutines started afterwards:
+//
+//	pprof.SetGoroutineLabels(buildinfo.WithPprofLabels(context.Background()))
+func WithPprofLabels(ctx context.Context) context.Context {
+	return pprof.WithLabels(ctx, pprof.Labels("version", releaseVersion, "build_hash", buildHash))
+}
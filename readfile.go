@@ -0,0 +1,219 @@
+package buildinfo
+
+import (
+	"bytes"
+	gobuildinfo "debug/buildinfo"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const ldflagsPrefix = "github.com/daaku/buildinfo."
+
+// File is the build information of a Go binary on disk.
+type File struct {
+	Info Info
+
+	// Branch, Tag and CommitSubject mirror BuildBranch, BuildTag and
+	// CommitSubject.
+	Branch        string
+	Tag           string
+	CommitSubject string
+
+	// Dirty mirrors Dirty.
+	Dirty bool
+
+	// Retracted and Deprecated mirror IsRetracted and DeprecationMessage.
+	Retracted  bool
+	Deprecated string
+
+	// VersionInfo are the strings of the VERSIONINFO resource of a Windows
+	// binary, such as "FileVersion" and "ProductName".
+	VersionInfo map[string]string
+
+	// BuildID is the identifier recorded by the linker, which is the LC_UUID
+	// of a macOS binary, or the CodeView GUID and age of a Windows binary.
+	BuildID string
+}
+
+// ReadFile reads the build information of the Go binary at path, such as an
+// artifact about to be deployed.
+//
+// The values provided via ldflags are recovered from the flags recorded by the
+// go command, which does not record them for binaries built with -trimpath. As
+// for the running binary, the VCS information and main module version are used
+// as a fallback. The VERSIONINFO resource and linker build ID of Windows and
+// macOS binaries are read when present.
+func ReadFile(path string) (*File, error) {
+	bi, err := gobuildinfo.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("buildinfo: %w", err)
+	}
+
+	vars := map[string]string{
+		"buildTimeUnix":  "0",
+		"buildHash":      "dev",
+		"releaseVersion": "dev",
+	}
+	for _, s := range bi.Settings {
+		if s.Key == "-ldflags" {
+			for k, v := range ldflagsVars(s.Value) {
+				vars[k] = v
+			}
+		}
+	}
+	hash, timeUnix, modified := vcsValues(bi.Settings)
+	if vars["buildHash"] == "dev" && hash != "" {
+		vars["buildHash"] = hash
+	}
+	if vars["buildTimeUnix"] == "0" && timeUnix != "" {
+		vars["buildTimeUnix"] = timeUnix
+	}
+	if v, ok := vars["buildDirty"]; ok {
+		modified, _ = strconv.ParseBool(v)
+	}
+	vars["buildHash"] = dirtyHash(vars["buildHash"], modified)
+	if v := bi.Main.Version; vars["releaseVersion"] == "dev" && v != "" && v != "(devel)" {
+		vars["releaseVersion"] = v
+	}
+
+	f := &File{
+		Info: Info{
+			ReleaseVersion: vars["releaseVersion"],
+			BuildHash:      vars["buildHash"],
+			BuildURL:       vars["buildURL"],
+			GoVersion:      bi.GoVersion,
+			Modules:        make([]Module, 0, len(bi.Deps)),
+		},
+		Branch:        vars["buildBranch"],
+		Tag:           vars["buildTag"],
+		CommitSubject: vars["commitSubject"],
+		Dirty:         modified,
+		Deprecated:    vars["deprecatedMessage"],
+	}
+	f.Retracted, _ = strconv.ParseBool(vars["isRetracted"])
+	if t, err := parseBuildTime(vars["buildTimeUnix"]); err == nil && t.Unix() != 0 {
+		f.Info.BuildTime = t
+	}
+	for _, m := range bi.Deps {
+		f.Info.Modules = append(f.Info.Modules, newModule(m))
+	}
+	f.readResources(path)
+	return f, nil
+}
+
+// ldflagsVars returns the buildinfo variables set using -X in the recorded
+// ldflags.
+func ldflagsVars(ldflags string) map[string]string {
+	vars := map[string]string{}
+	args := splitQuoted(ldflags)
+	for i := 0; i < len(args); i++ {
+		arg := strings.TrimPrefix(args[i], "-")
+		var def string
+		switch {
+		case arg == "-X" || arg == "X":
+			if i+1 < len(args) {
+				i++
+				def = args[i]
+			}
+		case strings.HasPrefix(arg, "-X=") || strings.HasPrefix(arg, "X="):
+			def = arg[strings.Index(arg, "=")+1:]
+		}
+		name, value, ok := strings.Cut(strings.TrimPrefix(def, ldflagsPrefix), "=")
+		if ok && strings.HasPrefix(def, ldflagsPrefix) {
+			vars[name] = value
+		}
+	}
+	return vars
+}
+
+// splitQuoted splits s into space separated fields, allowing single or double
+// quotes around fields, as the go command does for -ldflags.
+func splitQuoted(s string) []string {
+	var fields []string
+	for {
+		s = strings.TrimLeft(s, " \t\n\r")
+		if s == "" {
+			return fields
+		}
+		if q := s[0]; q == '\'' || q == '"' {
+			end := strings.IndexByte(s[1:], q)
+			if end < 0 {
+				return append(fields, s[1:])
+			}
+			fields = append(fields, s[1:end+1])
+			s = s[end+2:]
+			continue
+		}
+		end := strings.IndexAny(s, " \t\n\r")
+		if end < 0 {
+			return append(fields, s)
+		}
+		fields = append(fields, s[:end])
+		s = s[end:]
+	}
+}
+
+// BasicInfo returns the same report as the package level BasicInfo, for the
+// binary on disk.
+func (f *File) BasicInfo(opts ...Option) []byte {
+	var b bytes.Buffer
+	_ = f.WriteBasicInfo(&b, opts...)
+	return b.Bytes()
+}
+
+// WriteBasicInfo writes f.BasicInfo(opts...) to w.
+func (f *File) WriteBasicInfo(w io.Writer, opts ...Option) error {
+	o := newOptions(opts)
+	sw := &stickyWriter{w: w}
+	if f.Retracted {
+		fmt.Fprint(sw, "WARNING: this version has been retracted\n")
+	}
+	tw := tabwriter.NewWriter(sw, 0, 0, 1, ' ', 0)
+	if !f.Info.BuildTime.IsZero() {
+		fmt.Fprintf(tw, "Build Time:\t%s (%s ago)\n", o.formatTime(f.Info.BuildTime),
+			humanizeDuration(time.Since(f.Info.BuildTime), o.precision))
+	}
+	fmt.Fprintf(tw, "Release Version:\t%s\n", f.Info.ReleaseVersion)
+	fmt.Fprintf(tw, "Go Version:\t%s\n", f.Info.GoVersion)
+	fmt.Fprintf(tw, "Build Hash:\t%s\n", f.Info.BuildHash)
+	if f.Info.BuildURL != "" {
+		fmt.Fprintf(tw, "Build URL:\t%s\n", f.Info.BuildURL)
+	}
+	if f.Branch != "" {
+		fmt.Fprintf(tw, "Branch:\t%s\n", f.Branch)
+	}
+	if f.Tag != "" {
+		fmt.Fprintf(tw, "Tag:\t%s\n", f.Tag)
+	}
+	if f.CommitSubject != "" {
+		fmt.Fprintf(tw, "Commit Subject:\t%s\n", f.CommitSubject)
+	}
+	if f.Deprecated != "" {
+		fmt.Fprintf(tw, "Deprecated:\t%s\n", f.Deprecated)
+	}
+	if f.BuildID != "" {
+		fmt.Fprintf(tw, "Build ID:\t%s\n", f.BuildID)
+	}
+	for _, k := range sortedKeys(f.VersionInfo) {
+		fmt.Fprintf(tw, "%s:\t%s\n", k, f.VersionInfo[k])
+	}
+	_ = tw.Flush()
+	return sw.err
+}
+
+// ModuleInfo returns the same report as the package level ModuleInfo, for the
+// binary on disk.
+func (f *File) ModuleInfo() string {
+	var b bytes.Buffer
+	_ = f.WriteModuleInfo(&b)
+	return b.String()
+}
+
+// WriteModuleInfo writes f.ModuleInfo() to w.
+func (f *File) WriteModuleInfo(w io.Writer) error {
+	return writeModules(w, f.Info.Modules, ModuleInfoOpts{})
+}
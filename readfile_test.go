@@ -0,0 +1,77 @@
+package buildinfo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitQuoted(t *testing.T) {
+	cases := []struct {
+		in   string
+		want []string
+	}{
+		{in: "", want: nil},
+		{in: "  \t\n", want: nil},
+		{in: "-s -w", want: []string{"-s", "-w"}},
+		{in: "  -s\t\t-w\n", want: []string{"-s", "-w"}},
+		{in: `-X 'a.b=c d'`, want: []string{"-X", "a.b=c d"}},
+		{in: `-X "a.b=it's"`, want: []string{"-X", "a.b=it's"}},
+		{in: `'' x`, want: []string{"", "x"}},
+		{in: `-X 'a.b=unterminated`, want: []string{"-X", "a.b=unterminated"}},
+		{in: `"`, want: []string{""}},
+	}
+	for _, c := range cases {
+		if got := splitQuoted(c.in); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("splitQuoted(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestLdflagsVars(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want map[string]string
+	}{
+		{name: "empty", in: "", want: map[string]string{}},
+		{
+			name: "separate argument",
+			in:   "-s -w -X github.com/daaku/buildinfo.buildHash=abc",
+			want: map[string]string{"buildHash": "abc"},
+		},
+		{
+			name: "double dash and equals",
+			in:   "--X=github.com/daaku/buildinfo.buildHash=abc -X=github.com/daaku/buildinfo.releaseVersion=v1",
+			want: map[string]string{"buildHash": "abc", "releaseVersion": "v1"},
+		},
+		{
+			name: "quoted value",
+			in:   `-X 'github.com/daaku/buildinfo.commitSubject=Fix a=b bug'`,
+			want: map[string]string{"commitSubject": "Fix a=b bug"},
+		},
+		{
+			name: "empty value",
+			in:   "-X github.com/daaku/buildinfo.buildURL=",
+			want: map[string]string{"buildURL": ""},
+		},
+		{
+			name: "last wins",
+			in:   "-X github.com/daaku/buildinfo.buildHash=a -X github.com/daaku/buildinfo.buildHash=b",
+			want: map[string]string{"buildHash": "b"},
+		},
+		{
+			name: "other packages",
+			in:   "-X main.version=1 -X github.com/daaku/buildinfo2.buildHash=x -X github.com/other/buildinfo.buildHash=y",
+			want: map[string]string{},
+		},
+		{name: "missing definition", in: "-X", want: map[string]string{}},
+		{name: "missing value", in: "-X github.com/daaku/buildinfo.buildHash", want: map[string]string{}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ldflagsVars(c.in); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %q, want %q", got, c.want)
+			}
+		})
+	}
+}
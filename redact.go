@@ -0,0 +1,61 @@
+package buildinfo
+
+import (
+	"net/url"
+	"strings"
+)
+
+// redactedText replaces the redacted values.
+const redactedText = "[redacted]"
+
+var redactPatterns []string
+
+// Redact masks the module paths matching one of the patterns in all rendered
+// output, as well as the build and release notes URLs if their host and path
+// match one. This allows exposing the build information publicly without
+// leaking internal repository names. Patterns have the same form as in
+// ModuleInfoOpts.Include, such as "github.com/mycompany/...". BuildURL() and
+// SBOM() are not affected. It must be called before the build information is
+// used concurrently.
+func Redact(patterns ...string) {
+	redactPatterns = append(redactPatterns, patterns...)
+	render()
+}
+
+// redactModules returns a copy of mods with the matching paths masked.
+func redactModules(mods []Module) []Module {
+	if mods == nil {
+		return nil
+	}
+	redacted := make([]Module, len(mods))
+	for i, m := range mods {
+		redacted[i] = redactModule(m)
+	}
+	return redacted
+}
+
+func redactModule(m Module) Module {
+	if matchAny(redactPatterns, m.Path) {
+		m.Path = redactedText
+	}
+	if m.Replace != nil {
+		r := redactModule(*m.Replace)
+		m.Replace = &r
+	}
+	return m
+}
+
+// redactURL masks rawURL if its host and path match one of the patterns.
+func redactURL(rawURL string) string {
+	if rawURL == "" || len(redactPatterns) == 0 {
+		return rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return redactedText
+	}
+	if matchAny(redactPatterns, u.Host+strings.TrimSuffix(u.Path, "/")) {
+		return redactedText
+	}
+	return rawURL
+}
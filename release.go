@@ -0,0 +1,32 @@
+package buildinfo
+
+import (
+	"errors"
+	"strings"
+)
+
+// RequireRelease returns an error if this is not a release build, because the
+// release version or build hash are "dev", the build time is not known or a
+// value provided via ldflags is invalid. It is meant to be checked during
+// startup of binaries that must never be deployed without their build
+// information, such as when a renamed variable silently turns the -X flags
+// into no-ops.
+func RequireRelease() error {
+	var problems []string
+	if releaseVersion == "dev" {
+		problems = append(problems, `release version is "dev"`)
+	}
+	if buildHash == "dev" {
+		problems = append(problems, `build hash is "dev"`)
+	}
+	if buildTimeUnix == "0" {
+		problems = append(problems, "build time is not set")
+	}
+	if initErr != nil {
+		problems = append(problems, strings.TrimPrefix(initErr.Error(), "buildinfo: "))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New("buildinfo: not a release build: " + strings.Join(problems, ", "))
+}
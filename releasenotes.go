@@ -0,0 +1,87 @@
+package buildinfo
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+var (
+	releaseNotesMu       sync.Mutex
+	releaseNotesTemplate string
+)
+
+// SetReleaseNotesURLTemplate sets a custom template for ReleaseNotesURL. The
+// "{version}" placeholder is replaced with ReleaseVersion() and the "{hash}"
+// placeholder with BuildHash(). An empty template restores the default
+// behavior of deriving the URL from BuildURL().
+func SetReleaseNotesURLTemplate(tmpl string) {
+	releaseNotesMu.Lock()
+	defer releaseNotesMu.Unlock()
+	releaseNotesTemplate = tmpl
+}
+
+// ReleaseNotesURL returns the URL for the release notes of this release. It
+// is derived from BuildURL() for builds on GitHub, GitLab and Bitbucket, unless
+// a template was provided with SetReleaseNotesURLTemplate. It returns "" if
+// ReleaseVersion() is not a semantic version or the URL cannot be derived.
+func ReleaseNotesURL() string {
+	if _, ok := parseSemver(releaseVersion); !ok {
+		return ""
+	}
+	releaseNotesMu.Lock()
+	tmpl := releaseNotesTemplate
+	releaseNotesMu.Unlock()
+	if tmpl != "" {
+		return strings.NewReplacer(
+			"{version}", url.PathEscape(releaseVersion),
+			"{hash}", url.PathEscape(buildHash),
+		).Replace(tmpl)
+	}
+	return releaseNotesFromBuildURL(buildURL, releaseVersion)
+}
+
+func releaseNotesFromBuildURL(buildURL, version string) string {
+	repo, host := repoFromBuildURL(buildURL)
+	version = url.PathEscape(version)
+	switch host {
+	case "gitlab":
+		return repo + "/-/releases/" + version
+	case "github":
+		return repo + "/releases/tag/" + version
+	case "bitbucket":
+		return repo + "/src/" + version + "/"
+	}
+	return ""
+}
+
+// repoFromBuildURL returns the URL of the repository and the kind of host,
+// "github", "gitlab" or "bitbucket", for a CI build URL from one of them.
+func repoFromBuildURL(buildURL string) (repo, host string) {
+	u, err := url.Parse(buildURL)
+	if err != nil || u.Host == "" {
+		return "", ""
+	}
+	base := u.Scheme + "://" + u.Host
+	path := strings.Trim(u.Path, "/")
+
+	// GitLab: https://gitlab.com/group/project/-/pipelines/123
+	if i := strings.Index(path, "/-/"); i > 0 {
+		return base + "/" + path[:i], "gitlab"
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return "", ""
+	}
+	repo = base + "/" + parts[0] + "/" + parts[1]
+	switch {
+	// GitHub: https://github.com/owner/repo/actions/runs/123
+	case parts[2] == "actions":
+		return repo, "github"
+	// Bitbucket: https://bitbucket.org/workspace/repo/pipelines/results/123
+	case u.Host == "bitbucket.org" && (parts[2] == "pipelines" || parts[2] == "addon"):
+		return repo, "bitbucket"
+	}
+	return "", ""
+}
@@ -0,0 +1,51 @@
+package buildinfo
+
+import "testing"
+
+func TestRepoFromBuildURL(t *testing.T) {
+	cases := []struct {
+		in         string
+		repo, host string
+	}{
+		{
+			in:   "https://github.com/owner/repo/actions/runs/123",
+			repo: "https://github.com/owner/repo",
+			host: "github",
+		},
+		{
+			in:   "https://github.example.com/owner/repo/actions/runs/123/job/456",
+			repo: "https://github.example.com/owner/repo",
+			host: "github",
+		},
+		{
+			in:   "https://gitlab.com/group/subgroup/project/-/pipelines/789",
+			repo: "https://gitlab.com/group/subgroup/project",
+			host: "gitlab",
+		},
+		{
+			in:   "https://bitbucket.org/workspace/repo/pipelines/results/12",
+			repo: "https://bitbucket.org/workspace/repo",
+			host: "bitbucket",
+		},
+		{
+			in:   "https://bitbucket.org/workspace/repo/addon/pipelines/home#!/results/12",
+			repo: "https://bitbucket.org/workspace/repo",
+			host: "bitbucket",
+		},
+		{in: ""},
+		{in: "not a url"},
+		{in: "/owner/repo/actions/runs/123"},
+		{in: "https://github.com/owner/repo"},
+		{in: "https://github.com/owner"},
+		{in: "https://example.com/workspace/repo/pipelines/results/12"},
+		{in: "https://buildkite.com/org/pipeline/builds/17"},
+		{in: "https://gitlab.com/-/pipelines/1"},
+		{in: "http://[::1"},
+	}
+	for _, c := range cases {
+		repo, host := repoFromBuildURL(c.in)
+		if repo != c.repo || host != c.host {
+			t.Errorf("repoFromBuildURL(%q) = %q, %q, want %q, %q", c.in, repo, host, c.repo, c.host)
+		}
+	}
+}
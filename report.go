@@ -0,0 +1,146 @@
+package buildinfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+// maxReportBackoff caps the delay between report retries.
+const maxReportBackoff = time.Minute
+
+// ReportOpts controls what Report sends and how.
+type ReportOpts struct {
+	// Fields are the keys of the JSON encoding of Get() to send, such as
+	// "release_version" and "build_hash", along with "hostname" and "pid". No
+	// other information is sent, and at least one field must be listed.
+	Fields []string
+
+	// Header is added to the request, such as for authentication.
+	Header http.Header
+
+	// Client sends the request. It defaults to http.DefaultClient.
+	Client *http.Client
+
+	// Retries is the number of times a failed request is retried. Requests
+	// failing with a 4xx status other than 429 are not retried. It must not be
+	// negative.
+	Retries int
+
+	// Backoff is the delay before the first retry, which doubles for each
+	// subsequent retry up to one minute. It defaults to one second.
+	Backoff time.Duration
+
+	// DryRun logs the request using the log package instead of sending it.
+	DryRun bool
+}
+
+// Report sends the fields of the build information allowed by opts to
+// endpoint as a JSON object in a POST request, for a fleet inventory of which
+// build runs where. It is meant to be called once during startup, usually in
+// its own goroutine, and returns once the request succeeded, the retries are
+// exhausted or ctx is done.
+func Report(ctx context.Context, endpoint string, opts ReportOpts) error {
+	if opts.Retries < 0 {
+		return fmt.Errorf("buildinfo: negative report retries %d", opts.Retries)
+	}
+	body, err := reportBody(opts.Fields)
+	if err != nil {
+		return err
+	}
+	if opts.DryRun {
+		log.Printf("buildinfo: dry run, not sending report to %s: %s", endpoint, body)
+		return nil
+	}
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	backoff := opts.Backoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	backoff = min(backoff, maxReportBackoff)
+	for attempt := 0; ; attempt++ {
+		retry, err := sendReport(ctx, client, endpoint, opts.Header, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= opts.Retries {
+			return err
+		}
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+		backoff = min(2*backoff, maxReportBackoff)
+	}
+}
+
+// reportBody returns the JSON object with the allowed fields.
+func reportBody(fields []string) ([]byte, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("buildinfo: no fields allowed to be reported")
+	}
+	var all map[string]json.RawMessage
+	data, err := json.Marshal(Get())
+	if err != nil {
+		return nil, fmt.Errorf("buildinfo: %w", err)
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("buildinfo: %w", err)
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		all["hostname"], _ = json.Marshal(hostname)
+	}
+	all["pid"], _ = json.Marshal(os.Getpid())
+
+	selected := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		if v, ok := all[f]; ok {
+			selected[f] = v
+			continue
+		}
+		// Optional fields are left out of the encoding when empty.
+		switch f {
+		case "build_url", "modules", "custom", "hostname":
+			continue
+		}
+		return nil, fmt.Errorf("buildinfo: unknown report field %q", f)
+	}
+	return json.Marshal(selected)
+}
+
+// sendReport sends a single request, and returns whether a failure may be
+// retried.
+func sendReport(ctx context.Context, client *http.Client, endpoint string, header http.Header, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("buildinfo: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("buildinfo: %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+	retry = res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+	return retry, fmt.Errorf("buildinfo: unexpected status %s from %s", res.Status, endpoint)
+}
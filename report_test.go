@@ -0,0 +1,45 @@
+package buildinfo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReportRetries(t *testing.T) {
+	cases := []struct {
+		name     string
+		statuses []int
+		retries  int
+		wantErr  bool
+		wantSent int32
+	}{
+		{name: "ok", statuses: []int{200}, wantSent: 1},
+		{name: "retried until ok", statuses: []int{500, 429, 204}, retries: 2, wantSent: 3},
+		{name: "retries exhausted", statuses: []int{503, 503, 503, 503}, retries: 2, wantErr: true, wantSent: 3},
+		{name: "no retries", statuses: []int{500, 200}, wantErr: true, wantSent: 1},
+		{name: "client error", statuses: []int{400, 200}, retries: 2, wantErr: true, wantSent: 1},
+		{name: "negative retries", statuses: []int{200}, retries: -1, wantErr: true},
+	}
+	for _, c := range cases {
+		var sent atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(c.statuses[sent.Add(1)-1])
+		}))
+		err := Report(context.Background(), srv.URL, ReportOpts{
+			Fields:  []string{"pid"},
+			Retries: c.retries,
+			Backoff: time.Millisecond,
+		})
+		srv.Close()
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: got error %v, want error %v", c.name, err, c.wantErr)
+		}
+		if got := sent.Load(); got != c.wantSent {
+			t.Errorf("%s: sent %d requests, want %d", c.name, got, c.wantSent)
+		}
+	}
+}
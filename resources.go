@@ -0,0 +1,261 @@
+package buildinfo
+
+import (
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// readResources fills the metadata recorded outside of the Go build
+// information of the Windows or macOS binary at path, if any. Other formats,
+// and malformed resources, are ignored.
+func (f *File) readResources(path string) {
+	if p, err := pe.Open(path); err == nil {
+		defer p.Close()
+		f.VersionInfo = peVersionInfo(p)
+		f.BuildID = peBuildID(p)
+		return
+	}
+	if m, err := macho.Open(path); err == nil {
+		defer m.Close()
+		f.BuildID = machoUUID(m)
+		return
+	}
+	if fat, err := macho.OpenFat(path); err == nil {
+		defer fat.Close()
+		// The architectures of a universal binary are built from the same
+		// sources, so the first one stands for all of them.
+		if len(fat.Arches) > 0 {
+			f.BuildID = machoUUID(fat.Arches[0].File)
+		}
+	}
+}
+
+// machoUUID returns the LC_UUID of m, formatted as dwarfdump does.
+func machoUUID(m *macho.File) string {
+	const lcUUID = 0x1b
+	for _, l := range m.Loads {
+		raw := l.Raw()
+		if len(raw) < 24 || m.ByteOrder.Uint32(raw) != lcUUID {
+			continue
+		}
+		u := raw[8:24]
+		return strings.ToUpper(fmt.Sprintf("%x-%x-%x-%x-%x", u[:4], u[4:6], u[6:8], u[8:10], u[10:]))
+	}
+	return ""
+}
+
+// peDataDirectory returns the contents of the data directory with the given
+// index, such as the resources or debug information.
+func peDataDirectory(p *pe.File, index int) (rva uint32, data []byte) {
+	var dirs []pe.DataDirectory
+	switch h := p.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		dirs = h.DataDirectory[:min(int(h.NumberOfRvaAndSizes), len(h.DataDirectory))]
+	case *pe.OptionalHeader64:
+		dirs = h.DataDirectory[:min(int(h.NumberOfRvaAndSizes), len(h.DataDirectory))]
+	}
+	if index >= len(dirs) || dirs[index].VirtualAddress == 0 {
+		return 0, nil
+	}
+	d := dirs[index]
+	data = peRVA(p, d.VirtualAddress)
+	if uint32(len(data)) < d.Size {
+		return 0, nil
+	}
+	return d.VirtualAddress, data[:d.Size]
+}
+
+// peRVA returns the contents of the section containing the relative virtual
+// address rva, starting at rva.
+func peRVA(p *pe.File, rva uint32) []byte {
+	for _, s := range p.Sections {
+		if rva < s.VirtualAddress || rva >= s.VirtualAddress+max(s.VirtualSize, s.Size) {
+			continue
+		}
+		data, err := s.Data()
+		if err != nil || rva-s.VirtualAddress >= uint32(len(data)) {
+			return nil
+		}
+		return data[rva-s.VirtualAddress:]
+	}
+	return nil
+}
+
+// peBuildID returns the GUID and age of the CodeView debug information of p,
+// in the form used by symbol servers.
+func peBuildID(p *pe.File) string {
+	const (
+		debugDirectory = 6
+		entrySize      = 28
+		codeView       = 2
+	)
+	_, dir := peDataDirectory(p, debugDirectory)
+	for ; len(dir) >= entrySize; dir = dir[entrySize:] {
+		if binary.LittleEndian.Uint32(dir[12:]) != codeView {
+			continue
+		}
+		size := binary.LittleEndian.Uint32(dir[16:])
+		data := peRVA(p, binary.LittleEndian.Uint32(dir[20:]))
+		if size < 24 || uint32(len(data)) < size || string(data[:4]) != "RSDS" {
+			continue
+		}
+		g := data[4:20]
+		return fmt.Sprintf("%08X%04X%04X%X%x",
+			binary.LittleEndian.Uint32(g), binary.LittleEndian.Uint16(g[4:]),
+			binary.LittleEndian.Uint16(g[6:]), g[8:16], binary.LittleEndian.Uint32(data[20:]))
+	}
+	return ""
+}
+
+// peVersionInfo returns the strings of the first VERSIONINFO resource of p.
+func peVersionInfo(p *pe.File) map[string]string {
+	const (
+		resourceDirectory = 2
+		rtVersion         = 16
+	)
+	_, rsrc := peDataDirectory(p, resourceDirectory)
+	if rsrc == nil {
+		return nil
+	}
+	// The tree has the type, the name and the language as its levels, and
+	// the first name and language are used.
+	offset, ok := resourceEntry(rsrc, 0, rtVersion)
+	for level := 0; ok && level < 2; level++ {
+		offset, ok = resourceEntry(rsrc, offset, -1)
+	}
+	if !ok || offset&(1<<31) != 0 || int(offset)+8 > len(rsrc) {
+		return nil
+	}
+	data := peRVA(p, binary.LittleEndian.Uint32(rsrc[offset:]))
+	size := binary.LittleEndian.Uint32(rsrc[offset+4:])
+	if uint32(len(data)) < size {
+		return nil
+	}
+	return versionStrings(data[:size])
+}
+
+// resourceEntry returns the offset of the entry with the given ID in the
+// resource directory at offset, or of the first entry if id is negative.
+func resourceEntry(rsrc []byte, offset uint32, id int) (uint32, bool) {
+	offset &^= 1 << 31
+	if int(offset)+16 > len(rsrc) {
+		return 0, false
+	}
+	dir := rsrc[offset:]
+	n := int(binary.LittleEndian.Uint16(dir[12:])) + int(binary.LittleEndian.Uint16(dir[14:]))
+	for i := 0; i < n && 16+8*i+8 <= len(dir); i++ {
+		e := dir[16+8*i:]
+		if name := binary.LittleEndian.Uint32(e); id < 0 || name == uint32(id) {
+			return binary.LittleEndian.Uint32(e[4:]), true
+		}
+	}
+	return 0, false
+}
+
+// versionBlock is a node of a VS_VERSIONINFO structure.
+type versionBlock struct {
+	key      string
+	value    []byte
+	text     bool
+	children []byte
+}
+
+// readVersionBlock reads the block at the start of b, and returns it along
+// with the remaining data.
+func readVersionBlock(b []byte) (blk versionBlock, rest []byte, ok bool) {
+	if len(b) < 6 {
+		return blk, nil, false
+	}
+	length := int(binary.LittleEndian.Uint16(b))
+	valueLen := int(binary.LittleEndian.Uint16(b[2:]))
+	blk.text = binary.LittleEndian.Uint16(b[4:]) == 1
+	if length < 6 || length > len(b) {
+		return blk, nil, false
+	}
+	rest = b[min(align4(length), len(b)):]
+	b = b[:length]
+
+	i := 6
+	for ; i+1 < len(b) && binary.LittleEndian.Uint16(b[i:]) != 0; i += 2 {
+	}
+	blk.key = decodeUTF16(b[6:i])
+	i = align4(i + 2)
+	if blk.text {
+		// The length of text values is in characters.
+		valueLen *= 2
+	}
+	if i+valueLen > len(b) {
+		valueLen = max(len(b)-i, 0)
+	}
+	if i < len(b) {
+		blk.value = b[i : i+valueLen]
+	}
+	if i = align4(i + valueLen); i < len(b) {
+		blk.children = b[i:]
+	}
+	return blk, rest, true
+}
+
+// versionStrings returns the strings of the first table of the StringFileInfo
+// in the VS_VERSIONINFO structure data, or the file and product versions of
+// the fixed information if there are none.
+func versionStrings(data []byte) map[string]string {
+	root, _, ok := readVersionBlock(data)
+	if !ok || root.key != "VS_VERSION_INFO" {
+		return nil
+	}
+	values := map[string]string{}
+	for c := root.children; len(c) > 0; {
+		var info versionBlock
+		if info, c, ok = readVersionBlock(c); !ok {
+			break
+		}
+		if info.key != "StringFileInfo" {
+			continue
+		}
+		table, _, ok := readVersionBlock(info.children)
+		if !ok {
+			break
+		}
+		for s := table.children; len(s) > 0; {
+			var str versionBlock
+			if str, s, ok = readVersionBlock(s); !ok {
+				break
+			}
+			if v := strings.TrimRight(decodeUTF16(str.value), "\x00"); v != "" {
+				values[str.key] = v
+			}
+		}
+	}
+	// VS_FIXEDFILEINFO starts with its signature and version.
+	if v := root.value; len(values) == 0 && len(v) >= 24 && binary.LittleEndian.Uint32(v) == 0xfeef04bd {
+		values["FileVersion"] = fixedVersion(v[8:])
+		values["ProductVersion"] = fixedVersion(v[16:])
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// fixedVersion formats the version made up of the two words in b.
+func fixedVersion(b []byte) string {
+	ms, ls := binary.LittleEndian.Uint32(b), binary.LittleEndian.Uint32(b[4:])
+	return fmt.Sprintf("%d.%d.%d.%d", ms>>16, ms&0xffff, ls>>16, ls&0xffff)
+}
+
+func decodeUTF16(b []byte) string {
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	return string(utf16.Decode(u))
+}
+
+func align4(n int) int {
+	return (n + 3) &^ 3
+}
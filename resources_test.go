@@ -0,0 +1,381 @@
+package buildinfo
+
+import (
+	"bytes"
+	"debug/pe"
+	"encoding/binary"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+)
+
+// versionBlockBytes encodes a VS_VERSIONINFO block. The length of text values
+// is given in characters, as in the resources written by Windows tools.
+func versionBlockBytes(key string, text bool, value []byte, children ...[]byte) []byte {
+	b := make([]byte, 6)
+	for _, c := range utf16.Encode([]rune(key)) {
+		b = binary.LittleEndian.AppendUint16(b, c)
+	}
+	b = append(b, 0, 0)
+	b = pad4(b)
+	b = append(b, value...)
+	valueLen := len(value)
+	if text {
+		valueLen /= 2
+		binary.LittleEndian.PutUint16(b[4:], 1)
+	}
+	binary.LittleEndian.PutUint16(b[2:], uint16(valueLen))
+	for _, c := range children {
+		b = append(pad4(b), c...)
+	}
+	binary.LittleEndian.PutUint16(b, uint16(len(b)))
+	return b
+}
+
+func pad4(b []byte) []byte {
+	for len(b)%4 != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+func utf16z(s string) []byte {
+	var b []byte
+	for _, c := range utf16.Encode([]rune(s + "\x00")) {
+		b = binary.LittleEndian.AppendUint16(b, c)
+	}
+	return b
+}
+
+func fixedFileInfo(fileMS, fileLS, productMS, productLS uint32) []byte {
+	b := make([]byte, 52)
+	binary.LittleEndian.PutUint32(b, 0xfeef04bd)
+	binary.LittleEndian.PutUint32(b[8:], fileMS)
+	binary.LittleEndian.PutUint32(b[12:], fileLS)
+	binary.LittleEndian.PutUint32(b[16:], productMS)
+	binary.LittleEndian.PutUint32(b[20:], productLS)
+	return b
+}
+
+var testVersionInfo = versionBlockBytes("VS_VERSION_INFO", false, fixedFileInfo(1<<16|2, 3<<16|4, 5<<16, 0),
+	versionBlockBytes("StringFileInfo", true, nil,
+		versionBlockBytes("040904b0", true, nil,
+			versionBlockBytes("FileVersion", true, utf16z("1.2.3.4")),
+			versionBlockBytes("ProductName", true, utf16z("My App")),
+			versionBlockBytes("Comments", true, nil),
+		)),
+	versionBlockBytes("VarFileInfo", true, nil,
+		versionBlockBytes("Translation", false, []byte{0x09, 0x04, 0xb0, 0x04})),
+)
+
+func TestReadVersionBlock(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     []byte
+		ok       bool
+		key      string
+		value    []byte
+		text     bool
+		children int
+		rest     int
+	}{
+		{
+			name:  "binary value",
+			data:  versionBlockBytes("Translation", false, []byte{1, 2, 3, 4}),
+			ok:    true,
+			key:   "Translation",
+			value: []byte{1, 2, 3, 4},
+		},
+		{
+			name:  "text value",
+			data:  versionBlockBytes("ProductName", true, utf16z("App")),
+			ok:    true,
+			key:   "ProductName",
+			value: utf16z("App"),
+			text:  true,
+		},
+		{
+			name:     "children",
+			data:     versionBlockBytes("StringFileInfo", true, nil, versionBlockBytes("A", true, utf16z("b"))),
+			ok:       true,
+			key:      "StringFileInfo",
+			text:     true,
+			children: len(versionBlockBytes("A", true, utf16z("b"))),
+		},
+		{
+			name:  "followed by another block",
+			data:  append(pad4(versionBlockBytes("Key", false, []byte{1})), versionBlockBytes("Next", false, nil)...),
+			ok:    true,
+			key:   "Key",
+			value: []byte{1},
+			rest:  len(versionBlockBytes("Next", false, nil)),
+		},
+		{name: "empty", data: nil},
+		{name: "short header", data: []byte{6, 0, 0, 0}},
+		{name: "length below header", data: []byte{4, 0, 0, 0, 0, 0, 0, 0}},
+		{name: "length beyond data", data: []byte{64, 0, 0, 0, 0, 0, 'K', 0}},
+		{
+			name: "unterminated key",
+			data: []byte{10, 0, 0, 0, 0, 0, 'K', 0, 'e', 0},
+			ok:   true,
+			key:  "Ke",
+		},
+		{
+			name: "value beyond block",
+			data: []byte{12, 0, 100, 0, 0, 0, 'K', 0, 0, 0, 0, 0},
+			ok:   true,
+			key:  "K",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			blk, rest, ok := readVersionBlock(c.data)
+			if ok != c.ok {
+				t.Fatalf("got ok %v, want %v", ok, c.ok)
+			}
+			if !ok {
+				return
+			}
+			if blk.key != c.key {
+				t.Errorf("got key %q, want %q", blk.key, c.key)
+			}
+			if !bytes.Equal(blk.value, c.value) {
+				t.Errorf("got value %v, want %v", blk.value, c.value)
+			}
+			if blk.text != c.text {
+				t.Errorf("got text %v, want %v", blk.text, c.text)
+			}
+			if len(blk.children) != c.children {
+				t.Errorf("got %d bytes of children, want %d", len(blk.children), c.children)
+			}
+			if len(rest) != c.rest {
+				t.Errorf("got %d bytes of rest, want %d", len(rest), c.rest)
+			}
+		})
+	}
+}
+
+func TestVersionStrings(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+		want map[string]string
+	}{
+		{
+			name: "string table",
+			data: testVersionInfo,
+			want: map[string]string{"FileVersion": "1.2.3.4", "ProductName": "My App"},
+		},
+		{
+			name: "fixed information",
+			data: versionBlockBytes("VS_VERSION_INFO", false, fixedFileInfo(1<<16|2, 3<<16|4, 5<<16, 0)),
+			want: map[string]string{"FileVersion": "1.2.3.4", "ProductVersion": "5.0.0.0"},
+		},
+		{
+			name: "bad fixed signature",
+			data: versionBlockBytes("VS_VERSION_INFO", false, make([]byte, 52)),
+		},
+		{
+			name: "short fixed information",
+			data: versionBlockBytes("VS_VERSION_INFO", false, fixedFileInfo(1, 2, 3, 4)[:20]),
+		},
+		{
+			name: "wrong root key",
+			data: versionBlockBytes("VS_VERSION", false, fixedFileInfo(1, 2, 3, 4)),
+		},
+		{
+			name: "empty string table",
+			data: versionBlockBytes("VS_VERSION_INFO", false, nil, versionBlockBytes("StringFileInfo", true, nil)),
+		},
+		{name: "empty", data: nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := versionStrings(c.data); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestVersionStringsTruncated(t *testing.T) {
+	for n := range testVersionInfo {
+		data := testVersionInfo[:n]
+		versionStrings(data)
+
+		// A root length claiming the full size must not read past the data.
+		clone := append([]byte(nil), data...)
+		if len(clone) >= 2 {
+			binary.LittleEndian.PutUint16(clone, uint16(n))
+			versionStrings(clone)
+		}
+	}
+}
+
+const testSectionRVA = 0x1000
+
+// newTestPE returns a PE file with a single section of the given data, mapped
+// at testSectionRVA, and the data directories dirs.
+func newTestPE(t *testing.T, data []byte, dirs map[int]pe.DataDirectory) *pe.File {
+	t.Helper()
+	const dataOffset = 0x200
+	var b bytes.Buffer
+	dos := make([]byte, 64)
+	copy(dos, "MZ")
+	binary.LittleEndian.PutUint32(dos[0x3c:], 64)
+	b.Write(dos)
+	b.WriteString("PE\x00\x00")
+	oh := pe.OptionalHeader64{Magic: 0x20b, NumberOfRvaAndSizes: 16}
+	for i, d := range dirs {
+		oh.DataDirectory[i] = d
+	}
+	_ = binary.Write(&b, binary.LittleEndian, pe.FileHeader{
+		Machine:              pe.IMAGE_FILE_MACHINE_AMD64,
+		NumberOfSections:     1,
+		SizeOfOptionalHeader: uint16(binary.Size(oh)),
+	})
+	_ = binary.Write(&b, binary.LittleEndian, oh)
+	sh := pe.SectionHeader32{
+		VirtualSize:      uint32(len(data)),
+		VirtualAddress:   testSectionRVA,
+		SizeOfRawData:    uint32(len(data)),
+		PointerToRawData: dataOffset,
+	}
+	copy(sh.Name[:], ".rdata")
+	_ = binary.Write(&b, binary.LittleEndian, sh)
+	b.Write(make([]byte, dataOffset-b.Len()))
+	b.Write(data)
+	f, err := pe.NewFile(bytes.NewReader(b.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// debugDirectory returns a section starting with a debug directory entry of
+// the given type, followed by the entry data.
+func debugDirectory(typ uint32, data []byte) ([]byte, map[int]pe.DataDirectory) {
+	entry := make([]byte, 28)
+	binary.LittleEndian.PutUint32(entry[12:], typ)
+	binary.LittleEndian.PutUint32(entry[16:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(entry[20:], testSectionRVA+28)
+	return append(entry, data...), map[int]pe.DataDirectory{
+		6: {VirtualAddress: testSectionRVA, Size: 28},
+	}
+}
+
+func codeViewRecord(sig string) []byte {
+	b := []byte(sig)
+	for i := 1; i <= 16; i++ {
+		b = append(b, byte(i))
+	}
+	b = binary.LittleEndian.AppendUint32(b, 1)
+	return append(b, "app.pdb\x00"...)
+}
+
+func TestPEBuildID(t *testing.T) {
+	cases := []struct {
+		name string
+		typ  uint32
+		data []byte
+		dirs func(map[int]pe.DataDirectory)
+		want string
+	}{
+		{
+			name: "codeview",
+			typ:  2,
+			data: codeViewRecord("RSDS"),
+			want: "0403020106050807090A0B0C0D0E0F101",
+		},
+		{name: "other type", typ: 13, data: codeViewRecord("RSDS")},
+		{name: "wrong signature", typ: 2, data: codeViewRecord("NB10")},
+		{name: "truncated record", typ: 2, data: codeViewRecord("RSDS")[:20]},
+		{
+			name: "no debug directory",
+			typ:  2,
+			data: codeViewRecord("RSDS"),
+			dirs: func(d map[int]pe.DataDirectory) { delete(d, 6) },
+		},
+		{
+			name: "directory beyond section",
+			typ:  2,
+			data: codeViewRecord("RSDS"),
+			dirs: func(d map[int]pe.DataDirectory) {
+				d[6] = pe.DataDirectory{VirtualAddress: testSectionRVA, Size: 1 << 20}
+			},
+		},
+		{
+			name: "directory outside section",
+			typ:  2,
+			data: codeViewRecord("RSDS"),
+			dirs: func(d map[int]pe.DataDirectory) { d[6] = pe.DataDirectory{VirtualAddress: 0x9000, Size: 28} },
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, dirs := debugDirectory(c.typ, c.data)
+			if c.dirs != nil {
+				c.dirs(dirs)
+			}
+			if got := peBuildID(newTestPE(t, data, dirs)); got != c.want {
+				t.Errorf("got %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+// resourceSection returns a section with a resource tree holding data as the
+// first RT_VERSION resource.
+func resourceSection(data []byte) ([]byte, map[int]pe.DataDirectory) {
+	b := make([]byte, 3*24+16)
+	dir := func(offset int, id, to uint32) {
+		binary.LittleEndian.PutUint16(b[offset+14:], 1)
+		binary.LittleEndian.PutUint32(b[offset+16:], id)
+		binary.LittleEndian.PutUint32(b[offset+20:], to)
+	}
+	dir(0, 16, 1<<31|24)
+	dir(24, 1, 1<<31|48)
+	dir(48, 1033, 72)
+	binary.LittleEndian.PutUint32(b[72:], testSectionRVA+uint32(len(b)))
+	binary.LittleEndian.PutUint32(b[76:], uint32(len(data)))
+	b = append(b, data...)
+	return b, map[int]pe.DataDirectory{2: {VirtualAddress: testSectionRVA, Size: uint32(len(b))}}
+}
+
+func TestPEVersionInfo(t *testing.T) {
+	data, dirs := resourceSection(testVersionInfo)
+	want := map[string]string{"FileVersion": "1.2.3.4", "ProductName": "My App"}
+	if got := peVersionInfo(newTestPE(t, data, dirs)); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// The tree is walked with every possible truncation of the section.
+	for n := range data {
+		dirs := map[int]pe.DataDirectory{2: {VirtualAddress: testSectionRVA, Size: uint32(n)}}
+		peVersionInfo(newTestPE(t, data[:n], dirs))
+	}
+}
+
+func TestResourceEntry(t *testing.T) {
+	data, _ := resourceSection(nil)
+	cases := []struct {
+		name   string
+		offset uint32
+		id     int
+		want   uint32
+		ok     bool
+	}{
+		{name: "by id", offset: 0, id: 16, want: 1<<31 | 24, ok: true},
+		{name: "missing id", offset: 0, id: 3},
+		{name: "first", offset: 1<<31 | 24, id: -1, want: 1<<31 | 48, ok: true},
+		{name: "out of range", offset: uint32(len(data)), id: -1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, ok := resourceEntry(data, c.offset, c.id)
+			if got != c.want || ok != c.ok {
+				t.Errorf("got %#x, %v, want %#x, %v", got, ok, c.want, c.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package buildinfo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"text/tabwriter"
+)
+
+// RuntimeInfo provides a pretty table describing the environment the binary
+// is running in: GOMAXPROCS, the number of CPUs, the hostname, the PID and the
+// executable path.
+func RuntimeInfo() string {
+	var b bytes.Buffer
+	_ = WriteRuntimeInfo(&b)
+	return b.String()
+}
+
+// WriteRuntimeInfo writes RuntimeInfo() to w.
+func WriteRuntimeInfo(w io.Writer) error {
+	sw := &stickyWriter{w: w}
+	fmt.Fprint(sw, "Runtime:\n")
+	tw := tabwriter.NewWriter(sw, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(tw, "GOMAXPROCS\t%d\n", runtime.GOMAXPROCS(0))
+	fmt.Fprintf(tw, "NumCPU\t%d\n", runtime.NumCPU())
+	if hostname, err := os.Hostname(); err == nil {
+		fmt.Fprintf(tw, "Hostname\t%s\n", hostname)
+	}
+	fmt.Fprintf(tw, "PID\t%d\n", os.Getpid())
+	if exe, err := os.Executable(); err == nil {
+		fmt.Fprintf(tw, "Executable\t%s\n", exe)
+	}
+	_ = tw.Flush()
+	return sw.err
+}
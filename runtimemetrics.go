@@ -0,0 +1,93 @@
+package buildinfo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime/metrics"
+	"text/tabwriter"
+)
+
+// RuntimeMetrics is a snapshot of the state of the Go runtime, as reported by
+// the runtime/metrics package.
+type RuntimeMetrics struct {
+	// HeapInUseBytes is the memory occupied by heap spans, including both
+	// live objects and the unused space within their spans.
+	HeapInUseBytes uint64 `json:"heap_in_use_bytes"`
+
+	// TotalBytes is all the memory mapped by the runtime.
+	TotalBytes uint64 `json:"total_bytes"`
+
+	// GCCycles is the number of completed garbage collection cycles.
+	GCCycles uint64 `json:"gc_cycles"`
+
+	// Goroutines is the number of live goroutines.
+	Goroutines uint64 `json:"goroutines"`
+}
+
+// ReadRuntimeMetrics returns a snapshot of the state of the Go runtime.
+func ReadRuntimeMetrics() RuntimeMetrics {
+	samples := []metrics.Sample{
+		{Name: "/memory/classes/heap/objects:bytes"},
+		{Name: "/memory/classes/heap/unused:bytes"},
+		{Name: "/memory/classes/total:bytes"},
+		{Name: "/gc/cycles/total:gc-cycles"},
+		{Name: "/sched/goroutines:goroutines"},
+	}
+	metrics.Read(samples)
+	value := func(i int) uint64 {
+		if samples[i].Value.Kind() != metrics.KindUint64 {
+			return 0
+		}
+		return samples[i].Value.Uint64()
+	}
+	return RuntimeMetrics{
+		HeapInUseBytes: value(0) + value(1),
+		TotalBytes:     value(2),
+		GCCycles:       value(3),
+		Goroutines:     value(4),
+	}
+}
+
+// WithRuntimeMetrics includes the RuntimeMetricsInfo() section in FullInfo,
+// and a snapshot of the RuntimeMetrics in the JSON served by Handler.
+func WithRuntimeMetrics() Option {
+	return func(o *options) { o.runtimeMetrics = true }
+}
+
+// RuntimeMetricsInfo provides a pretty table with a snapshot of the state of
+// the Go runtime: the heap in use, the total memory, the number of garbage
+// collection cycles and the number of goroutines.
+func RuntimeMetricsInfo() string {
+	var b bytes.Buffer
+	_ = WriteRuntimeMetricsInfo(&b)
+	return b.String()
+}
+
+// WriteRuntimeMetricsInfo writes RuntimeMetricsInfo() to w.
+func WriteRuntimeMetricsInfo(w io.Writer) error {
+	m := ReadRuntimeMetrics()
+	sw := &stickyWriter{w: w}
+	fmt.Fprint(sw, "Runtime Metrics:\n")
+	tw := tabwriter.NewWriter(sw, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(tw, "Heap In Use\t%s\n", humanizeBytes(m.HeapInUseBytes))
+	fmt.Fprintf(tw, "Total Memory\t%s\n", humanizeBytes(m.TotalBytes))
+	fmt.Fprintf(tw, "GC Cycles\t%d\n", m.GCCycles)
+	fmt.Fprintf(tw, "Goroutines\t%d\n", m.Goroutines)
+	_ = tw.Flush()
+	return sw.err
+}
+
+// humanizeBytes formats n using binary units, such as "12.3 MiB".
+func humanizeBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,182 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// SBOMFormat is a software bill of materials document format.
+type SBOMFormat string
+
+const (
+	// CycloneDX is the CycloneDX 1.5 JSON format.
+	CycloneDX SBOMFormat = "cyclonedx"
+
+	// SPDX is the SPDX 2.3 JSON format.
+	SPDX SBOMFormat = "spdx"
+)
+
+const sbomTool = "github.com/daaku/buildinfo"
+
+// SBOM returns a minimal software bill of materials listing the modules
+// linked into this binary.
+func SBOM(format SBOMFormat) ([]byte, error) {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil, errors.New("buildinfo: build information not available")
+	}
+	deps := make([]Module, 0, len(bi.Deps))
+	for _, m := range bi.Deps {
+		// Replacements with a real version are other modules, while local
+		// directories are reported as "(devel)".
+		if m.Replace != nil && m.Replace.Version != "" && m.Replace.Version != "(devel)" {
+			m = m.Replace
+		}
+		deps = append(deps, Module{Path: m.Path, Version: m.Version})
+	}
+	main := Module{Path: bi.Main.Path, Version: releaseVersion}
+	switch format {
+	case CycloneDX:
+		return json.MarshalIndent(cycloneDX(main, deps), "", "  ")
+	case SPDX:
+		return json.MarshalIndent(spdx(main, deps), "", "  ")
+	}
+	return nil, fmt.Errorf("buildinfo: unknown SBOM format %q", format)
+}
+
+func purl(m Module) string {
+	segments := strings.Split(m.Path, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return "pkg:golang/" + strings.Join(segments, "/") + "@" + url.PathEscape(m.Version)
+}
+
+type cdxComponent struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	PURL    string `json:"purl,omitempty"`
+}
+
+type cdxDocument struct {
+	BOMFormat   string `json:"bomFormat"`
+	SpecVersion string `json:"specVersion"`
+	Version     int    `json:"version"`
+	Metadata    struct {
+		Timestamp string       `json:"timestamp,omitempty"`
+		Component cdxComponent `json:"component"`
+	} `json:"metadata"`
+	Components []cdxComponent `json:"components"`
+}
+
+func cycloneDX(main Module, deps []Module) cdxDocument {
+	doc := cdxDocument{BOMFormat: "CycloneDX", SpecVersion: "1.5", Version: 1}
+	if buildTimeUnix != "0" {
+		doc.Metadata.Timestamp = buildTime.UTC().Format(time.RFC3339)
+	}
+	doc.Metadata.Component = cdxComponent{
+		Type:    "application",
+		Name:    main.Path,
+		Version: main.Version,
+		PURL:    purl(main),
+	}
+	doc.Components = make([]cdxComponent, 0, len(deps))
+	for _, m := range deps {
+		doc.Components = append(doc.Components, cdxComponent{
+			Type:    "library",
+			Name:    m.Path,
+			Version: m.Version,
+			PURL:    purl(m),
+		})
+	}
+	return doc
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	SPDXID           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+type spdxDocument struct {
+	SPDXVersion       string `json:"spdxVersion"`
+	DataLicense       string `json:"dataLicense"`
+	SPDXID            string `json:"SPDXID"`
+	Name              string `json:"name"`
+	DocumentNamespace string `json:"documentNamespace"`
+	CreationInfo      struct {
+		Created  string   `json:"created"`
+		Creators []string `json:"creators"`
+	} `json:"creationInfo"`
+	Packages      []spdxPackage      `json:"packages"`
+	Relationships []spdxRelationship `json:"relationships"`
+}
+
+func spdx(main Module, deps []Module) spdxDocument {
+	doc := spdxDocument{
+		SPDXVersion: "SPDX-2.3",
+		DataLicense: "CC0-1.0",
+		SPDXID:      "SPDXRef-DOCUMENT",
+		Name:        main.Path,
+		DocumentNamespace: "https://spdx.org/spdxdocs/" + url.PathEscape(main.Path) +
+			"-" + url.PathEscape(main.Version) + "-" + url.PathEscape(buildHash),
+	}
+	created := time.Now()
+	if buildTimeUnix != "0" {
+		created = buildTime
+	}
+	doc.CreationInfo.Created = created.UTC().Format(time.RFC3339)
+	doc.CreationInfo.Creators = []string{"Tool: " + sbomTool}
+
+	pkg := func(id string, m Module) spdxPackage {
+		return spdxPackage{
+			Name:             m.Path,
+			SPDXID:           id,
+			VersionInfo:      m.Version,
+			DownloadLocation: "NOASSERTION",
+			ExternalRefs: []spdxExternalRef{{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  purl(m),
+			}},
+		}
+	}
+	const mainID = "SPDXRef-Package-main"
+	doc.Packages = append(doc.Packages, pkg(mainID, main))
+	doc.Relationships = append(doc.Relationships, spdxRelationship{
+		SPDXElementID:      doc.SPDXID,
+		RelationshipType:   "DESCRIBES",
+		RelatedSPDXElement: mainID,
+	})
+	for i, m := range deps {
+		id := fmt.Sprintf("SPDXRef-Package-%d", i+1)
+		doc.Packages = append(doc.Packages, pkg(id, m))
+		doc.Relationships = append(doc.Relationships, spdxRelationship{
+			SPDXElementID:      mainID,
+			RelationshipType:   "DEPENDS_ON",
+			RelatedSPDXElement: id,
+		})
+	}
+	return doc
+}
@@ -0,0 +1,154 @@
+package buildinfo
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// schemaVersion is bumped with every change to schema.json. 1.1.0 added
+// uptime_seconds, 1.2.0 custom, 1.3.0 the module sum and replace, 1.4.0
+// runtime_metrics, and 1.5.0 disallowed unknown properties.
+const schemaVersion = "1.5.0"
+
+//go:embed schema.json
+var jsonSchema []byte
+
+var (
+	parsedSchemaOnce sync.Once
+	parsedSchema     map[string]interface{}
+)
+
+// SchemaViolation describes a way in which a JSON document does not conform
+// to JSONSchema().
+type SchemaViolation struct {
+	// Path is the JSON Pointer to the offending value.
+	Path    string
+	Message string
+}
+
+func (v SchemaViolation) String() string {
+	return v.Path + ": " + v.Message
+}
+
+// JSONSchema returns the JSON Schema (draft 2020-12) describing the JSON
+// encoding of Info.
+func JSONSchema() []byte {
+	return append([]byte(nil), jsonSchema...)
+}
+
+// SchemaVersion returns the version of JSONSchema().
+func SchemaVersion() string {
+	return schemaVersion
+}
+
+// ValidateJSON validates data against JSONSchema(), returning the violations
+// found. It returns nil if data is valid.
+func ValidateJSON(data []byte) []SchemaViolation {
+	parsedSchemaOnce.Do(func() {
+		if err := json.Unmarshal(jsonSchema, &parsedSchema); err != nil {
+			panic(err)
+		}
+	})
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return []SchemaViolation{{Path: "", Message: err.Error()}}
+	}
+	var violations []SchemaViolation
+	validateSchema(parsedSchema, doc, "", &violations)
+	return violations
+}
+
+// validateSchema supports the subset of JSON Schema used by JSONSchema().
+func validateSchema(schema map[string]interface{}, v interface{}, path string, violations *[]SchemaViolation) {
+	add := func(format string, args ...interface{}) {
+		*violations = append(*violations, SchemaViolation{
+			Path:    path,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if t, ok := schema["type"].(string); ok && !schemaTypeMatches(t, v) {
+		add("expected %s, got %s", t, jsonTypeName(v))
+		return
+	}
+
+	switch v := v.(type) {
+	case string:
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				add("invalid date-time %q", v)
+			}
+		}
+	case map[string]interface{}:
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, r := range required {
+				name, _ := r.(string)
+				if _, ok := v[name]; !ok {
+					add("missing required property %q", name)
+				}
+			}
+		}
+		props, _ := schema["properties"].(map[string]interface{})
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			p := path + "/" + escapeJSONPointer(name)
+			if s, ok := props[name].(map[string]interface{}); ok {
+				validateSchema(s, v[name], p, violations)
+			} else if s, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+				validateSchema(s, v[name], p, violations)
+			} else if schema["additionalProperties"] == false {
+				*violations = append(*violations, SchemaViolation{Path: p, Message: "unexpected property"})
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				validateSchema(items, item, path+"/"+strconv.Itoa(i), violations)
+			}
+		}
+	}
+}
+
+func schemaTypeMatches(t string, v interface{}) bool {
+	switch t {
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == float64(int64(f))
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	}
+	return jsonTypeName(v) == t
+}
+
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
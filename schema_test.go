@@ -0,0 +1,85 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValidateJSONGet(t *testing.T) {
+	info := Get()
+	m := ReadRuntimeMetrics()
+	info.RuntimeMetrics = &m
+	info.Custom = map[string]string{"region": "us-east-1"}
+	info.Modules = append(info.Modules,
+		Module{Path: "example.com/a", Version: "v1.0.0", Sum: "h1:abc"},
+		Module{Path: "example.com/b", Version: "v1.0.0", Replace: &Module{Path: "../b"}},
+	)
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := ValidateJSON(data); v != nil {
+		t.Errorf("got violations %v for %s", v, data)
+	}
+}
+
+func TestValidateJSONViolations(t *testing.T) {
+	const valid = `"release_version": "v1", "build_hash": "abc", "build_time": "2024-01-02T03:04:05Z", ` +
+		`"go_version": "go1.22.0", "startup_time": "2024-01-02T03:04:05Z"`
+	cases := []struct {
+		name string
+		doc  string
+		want []SchemaViolation
+	}{
+		{name: "valid", doc: `{` + valid + `}`},
+		{name: "not json", doc: `{`, want: []SchemaViolation{{Path: "", Message: "unexpected end of JSON input"}}},
+		{name: "not object", doc: `[]`, want: []SchemaViolation{{Path: "", Message: "expected object, got array"}}},
+		{
+			name: "missing required",
+			doc:  `{"release_version": "v1", "build_time": "2024-01-02T03:04:05Z", "startup_time": "2024-01-02T03:04:05Z"}`,
+			want: []SchemaViolation{
+				{Path: "", Message: `missing required property "build_hash"`},
+				{Path: "", Message: `missing required property "go_version"`},
+			},
+		},
+		{
+			name: "wrong types",
+			doc:  `{` + valid + `, "uptime_seconds": 1.5, "build_url": 7, "custom": {"region": true}}`,
+			want: []SchemaViolation{
+				{Path: "/build_url", Message: "expected string, got number"},
+				{Path: "/custom/region", Message: "expected string, got boolean"},
+				{Path: "/uptime_seconds", Message: "expected integer, got number"},
+			},
+		},
+		{
+			name: "invalid date-time",
+			doc:  `{"release_version": "v1", "build_hash": "abc", "build_time": "yesterday", "go_version": "go1.22.0", "startup_time": "2024-01-02T03:04:05Z"}`,
+			want: []SchemaViolation{{Path: "/build_time", Message: `invalid date-time "yesterday"`}},
+		},
+		{
+			name: "nested",
+			doc:  `{` + valid + `, "modules": [{"path": "a", "version": "v1"}, {"path": "b", "replace": {"path": "c", "version": 1}}]}`,
+			want: []SchemaViolation{
+				{Path: "/modules/1", Message: `missing required property "version"`},
+				{Path: "/modules/1/replace/version", Message: "expected string, got number"},
+			},
+		},
+		{
+			name: "extra fields",
+			doc: `{` + valid + `, "a/b~c": 1, "modules": [{"path": "a", "version": "v1", "extra": 1}], ` +
+				`"runtime_metrics": {"heap_in_use_bytes": 1, "total_bytes": 1, "gc_cycles": 1, "goroutines": 1, "threads": 1}}`,
+			want: []SchemaViolation{
+				{Path: "/a~1b~0c", Message: "unexpected property"},
+				{Path: "/modules/0/extra", Message: "unexpected property"},
+				{Path: "/runtime_metrics/threads", Message: "unexpected property"},
+			},
+		},
+	}
+	for _, c := range cases {
+		got := ValidateJSON([]byte(c.doc))
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package buildinfo
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// SectionProvider contributes a section to FullInfo, such as the versions and
+// state of a database layer or feature flag client.
+type SectionProvider interface {
+	// Name is the title of the section.
+	Name() string
+
+	// Render writes the content of the section to w.
+	Render(w io.Writer) error
+}
+
+var (
+	sectionsMu       sync.Mutex
+	sectionProviders []SectionProvider
+)
+
+// AddSection adds a section to FullInfo, rendered after the built-in sections
+// other than the module list, in the order they were added. Adding a section
+// with an existing name replaces it.
+func AddSection(p SectionProvider) {
+	sectionsMu.Lock()
+	defer sectionsMu.Unlock()
+	for i, s := range sectionProviders {
+		if s.Name() == p.Name() {
+			sectionProviders[i] = p
+			return
+		}
+	}
+	sectionProviders = append(sectionProviders, p)
+}
+
+// providedSections returns the functions writing the added sections.
+func providedSections() []func(io.Writer) error {
+	sectionsMu.Lock()
+	providers := append([]SectionProvider(nil), sectionProviders...)
+	sectionsMu.Unlock()
+	writers := make([]func(io.Writer) error, len(providers))
+	for i, p := range providers {
+		p := p
+		writers[i] = func(w io.Writer) error {
+			if _, err := fmt.Fprintf(w, "%s:\n", p.Name()); err != nil {
+				return err
+			}
+			return p.Render(w)
+		}
+	}
+	return writers
+}
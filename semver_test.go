@@ -0,0 +1,91 @@
+package buildinfo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSemver(t *testing.T) {
+	cases := []struct {
+		in   string
+		want semver
+		ok   bool
+	}{
+		{in: "v1.2.3", want: semver{major: 1, minor: 2, patch: 3}, ok: true},
+		{in: "1.2.3", want: semver{major: 1, minor: 2, patch: 3}, ok: true},
+		{in: "v1.2", want: semver{major: 1, minor: 2}, ok: true},
+		{in: "v1", want: semver{major: 1}, ok: true},
+		{in: "v1.2.3-rc.1", want: semver{major: 1, minor: 2, patch: 3, pre: []string{"rc", "1"}}, ok: true},
+		{in: "v1.2.3+build.5", want: semver{major: 1, minor: 2, patch: 3}, ok: true},
+		{in: "v1.2.3-beta+build", want: semver{major: 1, minor: 2, patch: 3, pre: []string{"beta"}}, ok: true},
+		{
+			in:   "v0.0.0-20191010083416-a7dc8b61c822",
+			want: semver{pre: []string{"20191010083416-a7dc8b61c822"}},
+			ok:   true,
+		},
+		{in: ""},
+		{in: "v"},
+		{in: "dev"},
+		{in: "v1.2.3.4"},
+		{in: "v1..3"},
+		{in: "v1.2.x"},
+		{in: "v-1.2.3"},
+		{in: "v1.2.3-"},
+		{in: "v1.2.3-rc..1"},
+		{in: "v+1.2.3"},
+		{in: "v１.2.3"},
+	}
+	for _, c := range cases {
+		got, ok := parseSemver(c.in)
+		if ok != c.ok || !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseSemver(%q) = %+v, %v, want %+v, %v", c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestSemverCompare(t *testing.T) {
+	// Each version is lower than the ones following it, in the order given by
+	// the semantic versioning specification.
+	ordered := []string{
+		"v0.0.0-20191010083416-a7dc8b61c822",
+		"v0.9.0",
+		"v1.0.0-alpha",
+		"v1.0.0-alpha.1",
+		"v1.0.0-alpha.beta",
+		"v1.0.0-beta",
+		"v1.0.0-beta.2",
+		"v1.0.0-beta.11",
+		"v1.0.0-rc.1",
+		"v1.0.0",
+		"v1.0.1",
+		"v1.2.0",
+		"v1.10.0",
+		"v2.0.0",
+	}
+	for i, a := range ordered {
+		va, ok := parseSemver(a)
+		if !ok {
+			t.Fatalf("parseSemver(%q) failed", a)
+		}
+		for j, b := range ordered {
+			vb, _ := parseSemver(b)
+			want := compareInt(i, j)
+			if got := va.compare(vb); got != want {
+				t.Errorf("compare(%q, %q) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+
+	equal := [][2]string{
+		{"v1.2.3", "1.2.3"},
+		{"v1.2", "v1.2.0"},
+		{"v1.2.3+a", "v1.2.3+b"},
+	}
+	for _, e := range equal {
+		a, _ := parseSemver(e[0])
+		b, _ := parseSemver(e[1])
+		if got := a.compare(b); got != 0 {
+			t.Errorf("compare(%q, %q) = %d, want 0", e[0], e[1], got)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package buildinfo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"text/tabwriter"
+)
+
+// buildSettings contains the build settings recorded by the go command.
+var buildSettings = map[string]string{}
+
+func loadBuildSettings(settings []debug.BuildSetting) {
+	for _, s := range settings {
+		buildSettings[s.Key] = s.Value
+	}
+}
+
+// GOOS returns the operating system this binary was built for.
+func GOOS() string {
+	if v := buildSettings["GOOS"]; v != "" {
+		return v
+	}
+	return runtime.GOOS
+}
+
+// GOARCH returns the architecture this binary was built for.
+func GOARCH() string {
+	if v := buildSettings["GOARCH"]; v != "" {
+		return v
+	}
+	return runtime.GOARCH
+}
+
+// CGOEnabled returns true if this binary was built with cgo enabled.
+func CGOEnabled() bool {
+	return buildSettings["CGO_ENABLED"] == "1"
+}
+
+// BuildTags returns the build tags this binary was built with.
+func BuildTags() []string {
+	if v := buildSettings["-tags"]; v != "" {
+		return strings.Split(v, ",")
+	}
+	return nil
+}
+
+// Trimpath returns true if this binary was built with -trimpath.
+func Trimpath() bool {
+	return buildSettings["-trimpath"] == "true"
+}
+
+// Compiler returns the compiler toolchain this binary was built with.
+func Compiler() string {
+	if v := buildSettings["-compiler"]; v != "" {
+		return v
+	}
+	return runtime.Compiler
+}
+
+// BuildSettingsInfo provides a pretty table with the platform, cgo, build tags,
+// trimpath and compiler settings of this binary.
+func BuildSettingsInfo() string {
+	var b bytes.Buffer
+	_ = WriteBuildSettingsInfo(&b)
+	return b.String()
+}
+
+// WriteBuildSettingsInfo writes BuildSettingsInfo() to w.
+func WriteBuildSettingsInfo(w io.Writer) error {
+	sw := &stickyWriter{w: w}
+	fmt.Fprint(sw, "Build Settings:\n")
+	tw := tabwriter.NewWriter(sw, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(tw, "GOOS/GOARCH\t%s/%s\n", GOOS(), GOARCH())
+	fmt.Fprintf(tw, "CGO_ENABLED\t%t\n", CGOEnabled())
+	if tags := BuildTags(); len(tags) > 0 {
+		fmt.Fprintf(tw, "Tags\t%s\n", strings.Join(tags, ","))
+	}
+	fmt.Fprintf(tw, "Trimpath\t%t\n", Trimpath())
+	fmt.Fprintf(tw, "Compiler\t%s\n", Compiler())
+	_ = tw.Flush()
+	return sw.err
+}
@@ -0,0 +1,34 @@
+package buildinfo
+
+import (
+	"runtime"
+	"strings"
+)
+
+// Short returns a short human readable version string, such as:
+//
+//	v1.4.2 (abc1234)
+func Short() string {
+	return displayVersion() + " (" + buildHash + ")"
+}
+
+// Long returns a long human readable version string, such as:
+//
+//	v1.4.2 (abc1234, built 2024-05-01, go1.22.1)
+func Long() string {
+	details := []string{buildHash}
+	if buildTimeUnix != "0" {
+		details = append(details, "built "+buildTime.UTC().Format("2006-01-02"))
+	}
+	details = append(details, runtime.Version())
+	return displayVersion() + " (" + strings.Join(details, ", ") + ")"
+}
+
+// displayVersion returns the release version, prefixed with "v" if it is a
+// semantic version without one.
+func displayVersion() string {
+	if _, ok := parseSemver(releaseVersion); ok && !strings.HasPrefix(releaseVersion, "v") {
+		return "v" + releaseVersion
+	}
+	return releaseVersion
+}
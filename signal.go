@@ -0,0 +1,45 @@
+package buildinfo
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// DumpOnSignal writes FullInfo() to stderr whenever the process receives one
+// of sig. If no signals are given, SIGUSR1 is used on Unix systems. Calling
+// the returned function stops the dumping.
+func DumpOnSignal(sig ...os.Signal) (stop func()) {
+	return DumpOnSignalTo(os.Stderr, sig...)
+}
+
+// DumpOnSignalTo is like DumpOnSignal, but writes to w.
+func DumpOnSignalTo(w io.Writer, sig ...os.Signal) (stop func()) {
+	if len(sig) == 0 {
+		sig = defaultDumpSignals
+	}
+	if len(sig) == 0 {
+		return func() {}
+	}
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, sig...)
+	go func() {
+		for {
+			select {
+			case <-ch:
+				_ = WriteFullInfo(w)
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+		})
+	}
+}
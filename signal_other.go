@@ -0,0 +1,7 @@
+//go:build !unix
+
+package buildinfo
+
+import "os"
+
+var defaultDumpSignals []os.Signal
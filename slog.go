@@ -0,0 +1,19 @@
+package buildinfo
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer, logging the Info as a group.
+func (i Info) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("version", i.ReleaseVersion),
+		slog.String("hash", i.BuildHash),
+		slog.Time("build_time", i.BuildTime),
+		slog.Duration("uptime", i.Uptime),
+	)
+}
+
+// SlogGroup returns a "build" attribute group with the version, hash, build
+// time and uptime of this binary.
+func SlogGroup() slog.Attr {
+	return slog.Attr{Key: "build", Value: Get().LogValue()}
+}
@@ -0,0 +1,50 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteFileOnStart atomically writes the JSON encoded Get(), along with the
+// PID of the process, to path, replacing any existing file. The startup_time
+// is included in the JSON encoding. It is meant to be called early during
+// startup, so the build of a process that crashes before its logs are shipped
+// can still be identified from the file it left behind.
+func WriteFileOnStart(path string) error {
+	data, err := json.MarshalIndent(struct {
+		infoJSON
+		PID int `json:"pid"`
+	}{Get().encoded(), os.Getpid()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("buildinfo: %w", err)
+	}
+	data = append(data, '\n')
+
+	// Renaming a file within the same directory replaces the destination
+	// atomically, so readers never see a partially written file.
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("buildinfo: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("buildinfo: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("buildinfo: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("buildinfo: %w", err)
+	}
+	if err := os.Chmod(f.Name(), 0o644); err != nil {
+		return fmt.Errorf("buildinfo: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("buildinfo: %w", err)
+	}
+	return nil
+}
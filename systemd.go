@@ -0,0 +1,27 @@
+package buildinfo
+
+import (
+	"fmt"
+	"net"
+	"os"
+)
+
+// NotifySystemd tells systemd the service is ready, with a status showing the
+// build, such as "version v1.4.2 (abc1234)", which systemctl status displays.
+// It uses the sd_notify protocol, and does nothing when not running under
+// systemd with a notify socket.
+func NotifySystemd() error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return fmt.Errorf("buildinfo: notifying systemd: %w", err)
+	}
+	defer conn.Close()
+	if _, err := fmt.Fprintf(conn, "READY=1\nSTATUS=version %s", Short()); err != nil {
+		return fmt.Errorf("buildinfo: notifying systemd: %w", err)
+	}
+	return nil
+}
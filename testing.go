@@ -0,0 +1,86 @@
+//go:build testing
+
+package buildinfo
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// SetForTesting replaces the build information with the ReleaseVersion,
+// BuildHash, BuildTime, BuildURL and Modules from info, and returns a function
+// that restores the original values. Zero fields are treated as values that
+// were not provided, so an empty ReleaseVersion results in "dev", and Dirty()
+// reports whether the BuildHash has a "-dirty" suffix. All other values that
+// may be provided via ldflags, such as the branch, tag and retraction, are
+// reset to their defaults. It panics if called outside of a test binary, and
+// it must not be used by tests that run in parallel. It is only available when
+// building with the testing tag.
+func SetForTesting(info Info) (restore func()) {
+	if !testing.Testing() {
+		panic("buildinfo: SetForTesting called outside of a test binary")
+	}
+
+	orig := injectedValues()
+	origBuildTime, origModules := buildTime, modules
+	origDirty, origRetracted, origInitErr := dirty, retracted, initErr
+
+	for _, i := range injectable {
+		*i.v = i.dflt
+	}
+	releaseVersion = defaultString(info.ReleaseVersion, "dev")
+	buildHash = defaultString(info.BuildHash, "dev")
+	buildURL = info.BuildURL
+	dirty = strings.HasSuffix(buildHash, "-dirty")
+	retracted = false
+	initErr = nil
+	buildTime = time.Unix(0, 0)
+	if !info.BuildTime.IsZero() {
+		buildTimeUnix = strconv.FormatInt(info.BuildTime.Unix(), 10)
+		buildTime = time.Unix(info.BuildTime.Unix(), 0)
+	}
+	modules = append([]Module(nil), info.Modules...)
+	render()
+
+	return func() {
+		for key, value := range orig {
+			*injectable[key].v = value
+		}
+		buildTime, modules = origBuildTime, origModules
+		dirty, retracted, initErr = origDirty, origRetracted, origInitErr
+		render()
+	}
+}
+
+// VerifyInjected fails t unless the release version, build hash and build
+// time were provided via ldflags, ignoring the fallbacks to the information
+// stamped by the go command. It is meant to be used by a test run in CI with
+// the same ldflags as the release build, to catch wiring that silently broke:
+//
+//	go test -tags testing -ldflags "$LDFLAGS" ./...
+//
+// It is only available when building with the testing tag.
+func VerifyInjected(t testing.TB) {
+	t.Helper()
+	for _, v := range []struct{ key, name string }{
+		{"release_version", "releaseVersion"},
+		{"build_hash", "buildHash"},
+		{"build_time", "buildTimeUnix"},
+	} {
+		if injected[v.key] == injectable[v.key].dflt {
+			t.Errorf("buildinfo: %s was not provided via ldflags", ldflagsPrefix+v.name)
+		}
+	}
+	if initErr != nil {
+		t.Error(initErr)
+	}
+}
+
+func defaultString(s, dflt string) string {
+	if s == "" {
+		return dflt
+	}
+	return s
+}
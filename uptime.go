@@ -0,0 +1,60 @@
+package buildinfo
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WithPrecision sets the smallest unit shown by UptimeString, and for the
+// build age and uptime in BasicInfo and the outputs including it. It defaults
+// to, and may not be less than, a second.
+func WithPrecision(d time.Duration) Option {
+	return func(o *options) { o.precision = d }
+}
+
+// Uptime returns the time since StartupTime(), truncated to a second so it is
+// consistent across the JSON and text outputs.
+func Uptime() time.Duration {
+	return time.Since(startupTime).Truncate(time.Second)
+}
+
+// UptimeString returns the Uptime() in a humanized format, such as
+// "3d 4h 12m 5s". Units that are zero are left out.
+func UptimeString(opts ...Option) string {
+	return humanizeDuration(Uptime(), newOptions(opts).precision)
+}
+
+var durationUnits = []struct {
+	d      time.Duration
+	suffix string
+}{
+	{24 * time.Hour, "d"},
+	{time.Hour, "h"},
+	{time.Minute, "m"},
+	{time.Second, "s"},
+}
+
+// humanizeDuration formats d in days, hours, minutes and seconds, truncated to
+// precision.
+func humanizeDuration(d, precision time.Duration) string {
+	if precision < time.Second {
+		precision = time.Second
+	}
+	d = d.Truncate(precision)
+	var parts []string
+	for _, u := range durationUnits {
+		if n := d / u.d; n > 0 {
+			parts = append(parts, strconv.FormatInt(int64(n), 10)+u.suffix)
+			d -= n * u.d
+		}
+	}
+	if len(parts) == 0 {
+		for _, u := range durationUnits {
+			if u.d <= precision {
+				return "0" + u.suffix
+			}
+		}
+	}
+	return strings.Join(parts, " ")
+}
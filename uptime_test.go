@@ -0,0 +1,35 @@
+package buildinfo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanizeDuration(t *testing.T) {
+	const day = 24 * time.Hour
+	cases := []struct {
+		d, precision time.Duration
+		want         string
+	}{
+		{d: 0, precision: time.Second, want: "0s"},
+		{d: 999 * time.Millisecond, precision: time.Second, want: "0s"},
+		{d: 5 * time.Second, precision: time.Second, want: "5s"},
+		{d: 3*day + 4*time.Hour + 12*time.Minute + 5*time.Second, precision: time.Second, want: "3d 4h 12m 5s"},
+		{d: day + 5*time.Second, precision: time.Second, want: "1d 5s"},
+		{d: 2 * time.Hour, precision: time.Second, want: "2h"},
+		{d: 400 * day, precision: time.Second, want: "400d"},
+		{d: 3*day + 4*time.Hour + 12*time.Minute + 5*time.Second, precision: time.Minute, want: "3d 4h 12m"},
+		{d: 3*day + 4*time.Hour + 12*time.Minute, precision: time.Hour, want: "3d 4h"},
+		{d: 59 * time.Second, precision: time.Minute, want: "0m"},
+		{d: 5 * time.Hour, precision: day, want: "0d"},
+		{d: 90 * time.Minute, precision: 30 * time.Minute, want: "1h 30m"},
+		{d: 5 * time.Second, precision: 0, want: "5s"},
+		{d: 5*time.Second + 500*time.Millisecond, precision: time.Millisecond, want: "5s"},
+		{d: -5 * time.Second, precision: time.Second, want: "0s"},
+	}
+	for _, c := range cases {
+		if got := humanizeDuration(c.d, c.precision); got != c.want {
+			t.Errorf("humanizeDuration(%v, %v) = %q, want %q", c.d, c.precision, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package buildinfo
+
+import "runtime"
+
+// UserAgent returns a User-Agent header value identifying this build, such
+// as:
+//
+//	myapp/1.4.2 (abc1234; go1.22.1; linux/amd64)
+func UserAgent(product string) string {
+	return product + "/" + releaseVersion + " (" + buildHash + "; " +
+		runtime.Version() + "; " + runtime.GOOS + "/" + runtime.GOARCH + ")"
+}
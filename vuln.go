@@ -0,0 +1,217 @@
+package buildinfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	osvQueryBatchURL = "https://api.osv.dev/v1/querybatch"
+	osvMaxBatch      = 1000
+)
+
+// Vulnerability is a known vulnerability affecting a module linked into the
+// binary.
+type Vulnerability struct {
+	// ID is the OSV identifier, such as "GO-2024-2687".
+	ID     string
+	Module Module
+
+	// FixedVersion is the version of the module fixing the vulnerability, if
+	// known.
+	FixedVersion string
+}
+
+// Vulnerabilities is a list of findings. It implements SectionProvider, so it
+// can be added to FullInfo using AddSection.
+type Vulnerabilities []Vulnerability
+
+// VulnReport queries the OSV database at osv.dev for known vulnerabilities
+// affecting the versions of the modules linked into this binary, and of the
+// standard library it was built with, which is reported as the "stdlib"
+// module. It sends the module paths and versions to the OSV API, and so is
+// never called implicitly.
+func VulnReport(ctx context.Context) (Vulnerabilities, error) {
+	var pending []osvPending
+	for _, m := range modules {
+		v := effectiveVersion(m)
+		if v == "" || v == "(devel)" {
+			continue
+		}
+		pending = append(pending, osvPending{mod: Module{Path: m.Path, Version: v}})
+	}
+	// Development builds of the toolchain report versions such as
+	// "devel go1.23-abc", which OSV does not know.
+	if v := runtime.Version(); strings.HasPrefix(v, "go") && len(v) > 2 && v[2] >= '0' && v[2] <= '9' {
+		pending = append(pending, osvPending{mod: Module{Path: "stdlib", Version: v}})
+	}
+	var vulns Vulnerabilities
+	for len(pending) > 0 {
+		n := min(len(pending), osvMaxBatch)
+		found, next, err := osvQueryBatch(ctx, pending[:n])
+		if err != nil {
+			return nil, err
+		}
+		vulns = append(vulns, found...)
+		pending = append(pending[n:], next...)
+	}
+	return vulns, nil
+}
+
+// osvPending is a module to query, continuing from pageToken if set.
+type osvPending struct {
+	mod       Module
+	pageToken string
+}
+
+type osvQuery struct {
+	Package struct {
+		Name      string `json:"name"`
+		Ecosystem string `json:"ecosystem"`
+	} `json:"package"`
+	Version   string `json:"version"`
+	PageToken string `json:"page_token,omitempty"`
+}
+
+// osvQueryBatch returns the vulnerabilities found for the pending queries, and
+// the queries to continue with for those that have more results.
+func osvQueryBatch(ctx context.Context, pending []osvPending) (Vulnerabilities, []osvPending, error) {
+	var body struct {
+		Queries []osvQuery `json:"queries"`
+	}
+	for _, p := range pending {
+		var q osvQuery
+		q.Package.Name = p.mod.Path
+		q.Package.Ecosystem = "Go"
+		// OSV uses versions without the "v" prefix, and the go prefix of the
+		// Go version.
+		q.Version = strings.TrimPrefix(p.mod.Version, "v")
+		if p.mod.Path == "stdlib" {
+			q.Version = strings.TrimPrefix(p.mod.Version, "go")
+		}
+		q.PageToken = p.pageToken
+		body.Queries = append(body.Queries, q)
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, osvQueryBatchURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("buildinfo: unexpected status %s from %s", res.Status, osvQueryBatchURL)
+	}
+	var out struct {
+		Results []struct {
+			Vulns []struct {
+				ID string `json:"id"`
+			} `json:"vulns"`
+			NextPageToken string `json:"next_page_token"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return nil, nil, fmt.Errorf("buildinfo: invalid response from %s: %w", osvQueryBatchURL, err)
+	}
+	if len(out.Results) != len(pending) {
+		return nil, nil, fmt.Errorf("buildinfo: got %d results for %d queries from %s",
+			len(out.Results), len(pending), osvQueryBatchURL)
+	}
+	var vulns Vulnerabilities
+	var next []osvPending
+	for i, r := range out.Results {
+		for _, v := range r.Vulns {
+			vulns = append(vulns, Vulnerability{ID: v.ID, Module: pending[i].mod})
+		}
+		if r.NextPageToken != "" {
+			next = append(next, osvPending{mod: pending[i].mod, pageToken: r.NextPageToken})
+		}
+	}
+	return vulns, next, nil
+}
+
+// ParseGovulncheck reads the findings from the output of "govulncheck -json",
+// as an alternative to VulnReport that does not require network access from
+// the running binary.
+func ParseGovulncheck(r io.Reader) (Vulnerabilities, error) {
+	seen := map[string]bool{}
+	var vulns Vulnerabilities
+	dec := json.NewDecoder(r)
+	for {
+		var msg struct {
+			Finding *struct {
+				OSV          string `json:"osv"`
+				FixedVersion string `json:"fixed_version"`
+				Trace        []struct {
+					Module  string `json:"module"`
+					Version string `json:"version"`
+				} `json:"trace"`
+			} `json:"finding"`
+		}
+		if err := dec.Decode(&msg); err == io.EOF {
+			return vulns, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("buildinfo: invalid govulncheck output: %w", err)
+		}
+		f := msg.Finding
+		if f == nil || len(f.Trace) == 0 {
+			continue
+		}
+		v := Vulnerability{
+			ID:           f.OSV,
+			Module:       Module{Path: f.Trace[0].Module, Version: f.Trace[0].Version},
+			FixedVersion: f.FixedVersion,
+		}
+		// govulncheck reports a finding for every affected symbol.
+		if key := v.ID + "\x00" + v.Module.Path; !seen[key] {
+			seen[key] = true
+			vulns = append(vulns, v)
+		}
+	}
+}
+
+// Name returns the title of the section.
+func (v Vulnerabilities) Name() string {
+	return "Vulnerabilities"
+}
+
+// Render writes a table of the findings to w.
+func (v Vulnerabilities) Render(w io.Writer) error {
+	sw := &stickyWriter{w: w}
+	if len(v) == 0 {
+		fmt.Fprint(sw, "No known vulnerabilities\n")
+		return sw.err
+	}
+	tw := tabwriter.NewWriter(sw, 0, 0, 1, ' ', 0)
+	for _, f := range v {
+		fmt.Fprintf(tw, "%s\t%s\t%s", f.ID, f.Module.Path, f.Module.Version)
+		if f.FixedVersion != "" {
+			fmt.Fprintf(tw, "\tfixed in %s", f.FixedVersion)
+		}
+		fmt.Fprint(tw, "\n")
+	}
+	_ = tw.Flush()
+	return sw.err
+}
+
+// String returns the section, titled with Name().
+func (v Vulnerabilities) String() string {
+	var b bytes.Buffer
+	b.WriteString(v.Name() + ":\n")
+	_ = v.Render(&b)
+	return b.String()
+}